@@ -1,13 +1,43 @@
 package sqls
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// UnscopedPolicy decides whether an UPDATE or DELETE statement without a WHERE
+// clause may be built. allRows reports whether the statement was marked with AllRows.
+type UnscopedPolicy func(table string, allRows bool) error
+
+var (
+	// RequireAllRows refuses unscoped statements unless they are marked with AllRows.
+	RequireAllRows UnscopedPolicy = func(table string, allRows bool) error {
+		if allRows {
+			return nil
+		}
+		return fmt.Errorf("%w: %s, use AllRows to allow it", ErrUnscoped, table)
+	}
+	// AllowUnscoped allows all unscoped statements.
+	AllowUnscoped UnscopedPolicy = func(table string, allRows bool) error {
+		return nil
+	}
+	// DenyUnscoped refuses all unscoped statements, even those marked with AllRows.
+	DenyUnscoped UnscopedPolicy = func(table string, allRows bool) error {
+		return fmt.Errorf("%w: %s", ErrUnscoped, table)
+	}
 )
 
 type whereClause struct {
-	where []string
-	args  []any
+	where   []string
+	args    []any
+	invalid *BuildError
+	dialect *Dialect // set by the first clause, see d
 }
 
 // KeyVal is a key-value pair
@@ -16,92 +46,344 @@ type KeyVal struct {
 	val any
 }
 
+type join struct {
+	table string
+	on    []string
+}
+
+// dialectKind selects the syntax used for statements that differ between databases.
+type dialectKind int
+
+const (
+	kindDefault dialectKind = iota
+	kindPostgreSQL
+	kindMySQL
+	kindSQLServer
+	kindSQLite
+)
+
 // Dialect is a SQL dialect
 type Dialect struct {
 	placeholder string
 	paramCache  string
+	kind        dialectKind
+	maxParams   int
+	maxRows     int  // rows of a VALUES list, 0 for no limit
+	positional  bool // placeholders are bound by position and rendered without numbers
+	ordered     bool // placeholders are numbered in the order they appear
 }
 
 var (
 	// PostgreSQL dialect
 	PostgreSQL = Dialect{
 		placeholder: "$",
+		kind:        kindPostgreSQL,
+		maxParams:   65535,
 	}
 	// Dialect that uses @ placeholder
 	DefaultDialect = Dialect{
 		placeholder: "@",
+		maxParams:   999,
+		ordered:     true,
+	}
+	// MySQL dialect, its ? placeholders are bound in the order they appear
+	MySQL = Dialect{
+		placeholder: "?",
+		kind:        kindMySQL,
+		maxParams:   65535,
+		positional:  true,
+	}
+	// SQLServer dialect
+	SQLServer = Dialect{
+		placeholder: "@",
+		kind:        kindSQLServer,
+		maxParams:   2100,
+		maxRows:     1000,
+	}
+	// SQLite dialect, @1 is a named parameter numbered by its first appearance
+	SQLite = Dialect{
+		placeholder: "@",
+		kind:        kindSQLite,
+		maxParams:   999,
+		ordered:     true,
 	}
 )
 
-var curDialect Dialect
+// curDialect holds the current dialect. A stored dialect is never changed, so
+// SetDialect and statements being built in other goroutines do not race.
+var curDialect atomic.Pointer[Dialect]
 
-const MAX_PARAM_COUNT = 841 // 4096 characters
+// paramCaches holds the placeholder cache of each placeholder prefix, so it is
+// generated once and not every time the dialect is switched.
+var paramCaches sync.Map
+
+var unscopedPolicy atomic.Pointer[UnscopedPolicy]
+
+// MAX_PARAM_COUNT is the number of placeholders kept in the cache of each
+// dialect. Placeholders past it are formatted when the statement is built.
+const MAX_PARAM_COUNT = 841
 
 func init() {
+	for _, d := range []Dialect{DefaultDialect, PostgreSQL} {
+		paramCache(d.placeholder)
+	}
 	SetDialect(DefaultDialect)
+	SetUnscopedPolicy(RequireAllRows)
 }
 
-// SetDialect sets the SQL dialect to use for queries.
+// SetDialect sets the SQL dialect to use for queries. It is safe to call while
+// statements are being built in other goroutines; a statement keeps the
+// dialect that was current when its first clause was added.
 func SetDialect(dialect Dialect) {
-	curDialect = dialect
-	if curDialect.paramCache == "" {
-		curDialect.paramCache = generateParams(1, MAX_PARAM_COUNT)
+	if dialect.paramCache == "" {
+		dialect.paramCache = paramCache(dialect.placeholder)
 	}
+	curDialect.Store(&dialect)
 }
 
-func generateParams(start int, count int) string {
-	s := make([]string, 0, count)
-	last := start + count
-
-	for i := start; i < last; i++ {
-		s = append(s, curDialect.placeholder+strconv.Itoa(i))
+func paramCache(placeholder string) string {
+	if cache, ok := paramCaches.Load(placeholder); ok {
+		return cache.(string)
 	}
-	return strings.Join(s, ",")
+	d := Dialect{placeholder: placeholder}
+	cache, _ := paramCaches.LoadOrStore(placeholder, string(d.appendParams(nil, 1, MAX_PARAM_COUNT)))
+	return cache.(string)
 }
 
-func getIndex(n int) int {
-	// faster manual calculation, supports up to 999
-	if n <= 9 {
-		return 3 * (n - 1)
+// SetUnscopedPolicy sets the policy applied when building UPDATE and DELETE
+// statements without a WHERE clause. The default is RequireAllRows, nil
+// restores it.
+func SetUnscopedPolicy(policy UnscopedPolicy) {
+	if policy == nil {
+		policy = RequireAllRows
 	}
-	if n <= 99 {
-		return 3*9 + 4*(n-10)
+	unscopedPolicy.Store(&policy)
+}
+
+// paramOffset returns the offset of placeholder n in a list of placeholders
+// numbered from 1, where each placeholder takes the prefix, its digits and a comma.
+func paramOffset(n int, prefix int) int {
+	offset := 0
+	width := prefix + 2
+
+	for lo := 1; lo < n; lo *= 10 {
+		offset += (min(lo*10, n) - lo) * width
+		width++
 	}
-	return 3*9 + 4*90 + 5*(n-100)
+	return offset
+}
 
-	// slower dynamic calculation, supports any size
-	// chars := 3 // @#, @##,  @###,
-	// set := 9   // 1-9 10-99 100-999
-	// max := 10
-	// index := 0
+// appendParams appends count comma separated placeholders numbered from start.
+// Placeholders within the cache are copied from it, the others are formatted
+// into b, so it does not allocate when b has enough capacity.
+func (d *Dialect) appendParams(b []byte, start int, count int) []byte {
+	end := start + count
 
-	// for ; n > max; chars++ {
-	// 	index += chars * set
-	// 	set *= 10
-	// 	max *= 10
-	// }
-	// index += chars * (n - max/10)
-	// return index
+	if count > 0 && end-1 <= MAX_PARAM_COUNT && d.paramCache != "" {
+		x := paramOffset(start, len(d.placeholder))
+		y := paramOffset(end, len(d.placeholder)) - 1
+		return append(b, d.paramCache[x:y]...)
+	}
+	for i := start; i < end; i++ {
+		if i > start {
+			b = append(b, ',')
+		}
+		b = append(b, d.placeholder...)
+		b = strconv.AppendInt(b, int64(i), 10)
+	}
+	return b
 }
 
-func params(start int, count int) string {
+// params returns count comma separated placeholders numbered from start.
+func (d *Dialect) params(start int, count int) string {
 	end := start + count
 
-	if end >= MAX_PARAM_COUNT {
-		return generateParams(start, count)
+	if count > 0 && end-1 <= MAX_PARAM_COUNT {
+		x := paramOffset(start, len(d.placeholder))
+		y := paramOffset(end, len(d.placeholder)) - 1
+		return d.paramCache[x:y]
+	}
+	return string(d.appendParams(make([]byte, 0, count*(len(d.placeholder)+7)), start, count))
+}
+
+// d returns the dialect of the statement. It is loaded when the first clause
+// is added and kept, so a statement never mixes the placeholders or syntax of
+// two dialects when SetDialect is called while it is built.
+func (s *whereClause) d() *Dialect {
+	if s.dialect == nil {
+		s.dialect = curDialect.Load()
+	}
+	return s.dialect
+}
+
+// renderDialect returns the dialect to render the statement with: its own, or
+// the current one if no clause was added yet. Unlike d it does not store the
+// dialect, so rendering never writes to a statement shared between goroutines.
+func (s *whereClause) renderDialect() *Dialect {
+	if s.dialect != nil {
+		return s.dialect
 	}
-	x := getIndex(start)
-	y := getIndex(end) - 1
+	return curDialect.Load()
+}
+
+// dialectOf returns the dialect stmt is rendered with.
+func dialectOf(stmt Statement) *Dialect {
+	if s, ok := stmt.(interface{ renderDialect() *Dialect }); ok {
+		return s.renderDialect()
+	}
+	return curDialect.Load()
+}
 
-	return curDialect.paramCache[x:y]
+// chunkSize returns the number of rows of length values that fit in one
+// statement.
+func (d *Dialect) chunkSize(length int) int {
+	size := d.maxParams / max(length, 1)
+	if d.maxRows > 0 {
+		size = min(size, d.maxRows)
+	}
+	return max(size, 1)
+}
+
+// bindOrder makes the placeholders in b[start:] bind the arguments in the
+// order the placeholders appear, for the dialects whose drivers bind by
+// position. Statements number their placeholders when clauses are added, so
+// clauses can be rendered in another order than they were added. Other
+// dialects are returned as is.
+func (d *Dialect) bindOrder(b []byte, start int, args []any) ([]byte, []any) {
+	switch {
+	case d.positional:
+		return d.unnumber(b, start, args)
+	case d.ordered:
+		return d.renumber(b, start, args)
+	}
+	return b, args
+}
+
+// unnumber rewrites the numbered placeholders as the bare placeholder of a
+// positional dialect and returns the arguments in the order of the
+// placeholders, repeating those used more than once.
+func (d *Dialect) unnumber(b []byte, start int, args []any) ([]byte, []any) {
+	list := make([]any, 0, len(args))
+	w := start
+	for i := start; ; {
+		at, end, x := d.nextParam(b, i, len(args))
+		if at < 0 {
+			w += copy(b[w:], b[i:])
+			break
+		}
+		w += copy(b[w:], b[i:at])
+		w += copy(b[w:], d.placeholder)
+		list = append(list, args[x-1])
+		i = end
+	}
+	return b[:w], list
+}
+
+// renumber numbers the placeholders in the order they first appear, as
+// SQLite numbers its named parameters, and returns the arguments in that order.
+func (d *Dialect) renumber(b []byte, start int, args []any) ([]byte, []any) {
+	// most statements are already numbered in order
+	next := 1
+	for i := start; ; {
+		at, end, x := d.nextParam(b, i, len(args))
+		if at < 0 {
+			return b, args
+		}
+		if x > next {
+			break
+		}
+		if x == next {
+			next++
+		}
+		i = end
+	}
+
+	num := make([]int, len(args))
+	list := make([]any, 0, len(args))
+	out := make([]byte, 0, len(b)-start)
+	for i := start; ; {
+		at, end, x := d.nextParam(b, i, len(args))
+		if at < 0 {
+			out = append(out, b[i:]...)
+			break
+		}
+		if num[x-1] == 0 {
+			list = append(list, args[x-1])
+			num[x-1] = len(list)
+		}
+		out = append(out, b[i:at]...)
+		out = append(out, d.placeholder...)
+		out = strconv.AppendInt(out, int64(num[x-1]), 10)
+		i = end
+	}
+	return append(b[:start], out...), list
 }
 
+// nextParam returns the offset, the end and the number of the first
+// placeholder of the n arguments at or after i, which is not in a quoted
+// string, skipping quoted strings. The offset is -1 if there is none.
+func (d *Dialect) nextParam(b []byte, i int, n int) (int, int, int) {
+	ph := d.placeholder
+	quoted := false
+	for ; i < len(b); i++ {
+		if b[i] == '\'' {
+			quoted = !quoted
+		}
+		if quoted || !hasPrefixAt(b, i, ph) {
+			continue
+		}
+		j := i + len(ph)
+		x := 0
+		for j < len(b) && b[j] >= '0' && b[j] <= '9' && j-i-len(ph) < 10 {
+			x = x*10 + int(b[j]-'0')
+			j++
+		}
+		if x >= 1 && x <= n {
+			return i, j, x
+		}
+	}
+	return -1, len(b), 0
+}
+
+// isNull reports whether value is bound as NULL: nil, a nil pointer or a
+// driver.Valuer such as sql.NullString that returns nil.
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+		return true
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+	return false
+}
+
+// whereEquals adds a column=value clause, or column IS NULL for NULL values
+// since column=NULL never matches.
 func (s *whereClause) whereEquals(column string, value any) {
+	if isNull(value) {
+		s.whereNull(column)
+		return
+	}
 	s.args = append(s.args, value)
-	p := params(len(s.args), 1)
+	p := s.d().params(len(s.args), 1)
 	s.where = append(s.where, column+`=`+p)
 }
 
+// whereNotEquals adds a column<>value clause, or column IS NOT NULL for NULL values.
+func (s *whereClause) whereNotEquals(column string, value any) {
+	if isNull(value) {
+		s.whereNotNull(column)
+		return
+	}
+	s.args = append(s.args, value)
+	p := s.d().params(len(s.args), 1)
+	s.where = append(s.where, column+`<>`+p)
+}
+
 func (s *whereClause) whereNull(column string) {
 	s.where = append(s.where, column+` IS NULL`)
 }
@@ -110,19 +392,244 @@ func (s *whereClause) whereNotNull(column string) {
 	s.where = append(s.where, column+` IS NOT NULL`)
 }
 
+// whereExp adds a column operator value clause. Unknown operators are never
+// written to the query, the clause does not match and Build reports ErrInvalidOp.
 func (s *whereClause) whereExp(column string, ex string, value any) {
+	op, err := ParseOp(ex)
+	if err != nil {
+		if s.invalid == nil {
+			s.invalid = &BuildError{Err: ErrInvalidOp, Detail: strconv.Quote(ex)}
+		}
+		s.where = append(s.where, `1=0`)
+		return
+	}
+	if op == ILike || op == NotILike {
+		s.whereILike(column, op, value)
+		return
+	}
+	if op == Like || op == NotLike {
+		s.whereLike(column, op.sql(s.d()), value)
+		return
+	}
+
 	s.args = append(s.args, value)
-	p := params(len(s.args), 1)
-	s.where = append(s.where, column+ex+p)
+	p := s.d().params(len(s.args), 1)
+	if op == DistinctFrom && s.d().kind == kindMySQL {
+		s.where = append(s.where, `NOT `+column+`<=>`+p)
+		return
+	}
+	s.where = append(s.where, column+op.sql(s.d())+p)
+}
+
+func (s *whereClause) whereBetween(column string, op string, from any, to any) {
+	s.args = append(s.args, from, to)
+	p := s.d().params(len(s.args)-1, 1)
+	q := s.d().params(len(s.args), 1)
+	s.where = append(s.where, column+op+p+` AND `+q)
+}
+
+// whereLike adds a LIKE clause with an explicit escape character, since SQLite
+// and SQL Server have no default one.
+func (s *whereClause) whereLike(column string, op string, pattern any) {
+	s.args = append(s.args, pattern)
+	p := s.d().params(len(s.args), 1)
+	s.where = append(s.where, column+op+p+s.d().escapeSql())
+}
+
+// whereILike adds a case-insensitive LIKE clause, emulated with LOWER() on
+// dialects without ILIKE.
+func (s *whereClause) whereILike(column string, op Op, pattern any) {
+	s.args = append(s.args, pattern)
+	p := s.d().params(len(s.args), 1)
+
+	d := s.d()
+	if d.kind == kindPostgreSQL {
+		s.where = append(s.where, column+op.sql(d)+p+d.escapeSql())
+		return
+	}
+	like := Like
+	if op == NotILike {
+		like = NotLike
+	}
+	s.where = append(s.where, `LOWER(`+column+`)`+like.sql(d)+`LOWER(`+p+`)`+d.escapeSql())
 }
 
 func (s *whereClause) whereIn(column string, values []any) {
-	params := params(len(s.args)+1, len(values))
+	// an empty IN list never matches
+	if len(values) == 0 {
+		s.where = append(s.where, `1=0`)
+		return
+	}
+	params := s.d().params(len(s.args)+1, len(values))
 
 	s.where = append(s.where, column+` IN (`+params+`)`)
 	s.args = append(s.args, values...)
 }
 
+func (s *whereClause) whereNotIn(column string, values []any) {
+	// an empty NOT IN list always matches, so it adds no condition and an
+	// UPDATE or DELETE statement without other conditions stays unscoped
+	if len(values) == 0 {
+		return
+	}
+	params := s.d().params(len(s.args)+1, len(values))
+
+	s.where = append(s.where, column+` NOT IN (`+params+`)`)
+	s.args = append(s.args, values...)
+}
+
+// whereAny binds the whole slice as a single array argument on PostgreSQL and
+// falls back to an IN list on other dialects.
+func (s *whereClause) whereAny(column string, values any) {
+	if s.d().kind == kindPostgreSQL {
+		s.args = append(s.args, values)
+		p := s.d().params(len(s.args), 1)
+		s.where = append(s.where, column+`=ANY(`+p+`)`)
+		return
+	}
+	s.whereIn(column, expand(values))
+}
+
 func (s *whereClause) whereRaw(raw string) {
 	s.where = append(s.where, raw)
 }
+
+// clone returns a copy of the WHERE clause that does not share its slices.
+func (s *whereClause) clone() whereClause {
+	return whereClause{
+		where:   slices.Clone(s.where),
+		args:    slices.Clone(s.args),
+		invalid: s.invalid,
+		dialect: s.dialect,
+	}
+}
+
+// cloneJoins returns a copy of the joins that does not share their ON conditions.
+func cloneJoins(joins []join) []join {
+	if joins == nil {
+		return nil
+	}
+	c := make([]join, len(joins))
+	for i, j := range joins {
+		c[i] = join{table: j.table, on: slices.Clone(j.on)}
+	}
+	return c
+}
+
+// joinOn adds a column=value condition to the ON clause of the last join.
+// Without a join the condition is added to the WHERE clause instead.
+func (s *whereClause) joinOn(joins []join, column string, value any) {
+	if len(joins) == 0 {
+		s.whereEquals(column, value)
+		return
+	}
+	last := &joins[len(joins)-1]
+	if isNull(value) {
+		last.on = append(last.on, column+` IS NULL`)
+		return
+	}
+	s.args = append(s.args, value)
+	p := s.d().params(len(s.args), 1)
+	last.on = append(last.on, column+`=`+p)
+}
+
+// check returns the first error recorded while adding WHERE clauses.
+func (s *whereClause) check(statement string) error {
+	if s.invalid != nil {
+		return s.invalid.in(statement)
+	}
+	return nil
+}
+
+// checkScope applies the unscoped policy to statements without a WHERE clause.
+// A LIMIT does not scope a statement, it still affects arbitrary rows.
+func (s *whereClause) checkScope(table string, allRows bool) error {
+	if s.where != nil {
+		return nil
+	}
+	return (*unscopedPolicy.Load())(table, allRows)
+}
+
+// checkOrderLimit returns a *BuildError if ORDER BY and LIMIT cannot be
+// rendered in an UPDATE or DELETE statement for the dialect. They are native
+// on MySQL and SQLite and emulated with the key column on PostgreSQL.
+func checkOrderLimit(d *Dialect, statement string, key string, joined bool) error {
+	if joined {
+		return &BuildError{Statement: statement, Err: ErrNotSupported, Detail: "ORDER BY and LIMIT with joins"}
+	}
+	switch d.kind {
+	case kindMySQL, kindSQLite:
+		return nil
+	case kindPostgreSQL:
+		if key == "" {
+			return &BuildError{Statement: statement, Err: ErrNoKey, Detail: "ORDER BY and LIMIT need a Key on PostgreSQL"}
+		}
+		return nil
+	}
+	return &BuildError{Statement: statement, Err: ErrNotSupported, Detail: "ORDER BY and LIMIT"}
+}
+
+func (d *Dialect) escapeSql() string {
+	// backslash is an escape character in MySQL string literals
+	if d.kind == kindMySQL {
+		return ` ESCAPE '\\'`
+	}
+	return ` ESCAPE '\'`
+}
+
+// EscapeLike escapes the LIKE wildcards in s so it matches literally.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `[`, `\[`)
+
+// Contains returns a LIKE pattern that matches values containing s.
+func Contains(s string) string {
+	return "%" + EscapeLike(s) + "%"
+}
+
+// HasPrefix returns a LIKE pattern that matches values starting with s.
+func HasPrefix(s string) string {
+	return EscapeLike(s) + "%"
+}
+
+// HasSuffix returns a LIKE pattern that matches values ending with s.
+func HasSuffix(s string) string {
+	return "%" + EscapeLike(s)
+}
+
+// expand converts a slice or array to a []any. Other values, including []byte,
+// are returned as a single element.
+func expand(values any) []any {
+	if v, ok := values.([]any); ok {
+		return v
+	}
+	v := reflect.ValueOf(values)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return []any{values}
+	}
+
+	list := make([]any, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+	return list
+}
+
+// tableName returns the table of a table expression such as "users u".
+func tableName(table string) string {
+	if i := strings.IndexByte(table, ' '); i >= 0 {
+		return table[:i]
+	}
+	return table
+}
+
+// tableAlias returns the alias of a table expression such as "users u" or
+// "users AS u", or the table itself when it has no alias.
+func tableAlias(table string) string {
+	if i := strings.LastIndexByte(table, ' '); i >= 0 {
+		return table[i+1:]
+	}
+	return table
+}
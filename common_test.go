@@ -2,7 +2,12 @@ package sqls
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"unsafe"
 )
 
 func TestParams(t *testing.T) {
@@ -31,13 +36,16 @@ func TestParams(t *testing.T) {
 		{840, 3, "@840,@841,@842"},
 		{998, 5, "@998,@999,@1000,@1001,@1002"},
 		{999, 1, "@999"},
+		{1000, 1, "@1000"},
+		{9999, 3, "@9999,@10000,@10001"},
+		{65534, 2, "@65534,@65535"},
 	}
 
 	for _, tt := range tests {
 		name := fmt.Sprintf("params %d:%d", tt.start, tt.count)
 
 		t.Run(name, func(t *testing.T) {
-			got := params(tt.start, tt.count)
+			got := curDialect.Load().params(tt.start, tt.count)
 
 			if got != tt.want {
 				t.Errorf("want '%s', got '%s'", tt.want, got)
@@ -45,3 +53,259 @@ func TestParams(t *testing.T) {
 		})
 	}
 }
+
+func TestParamsPlaceholder(t *testing.T) {
+	defer SetDialect(DefaultDialect)
+	SetDialect(Dialect{placeholder: ":p"})
+
+	if got := curDialect.Load().params(9, 3); got != ":p9,:p10,:p11" {
+		t.Errorf("want ':p9,:p10,:p11', got '%s'", got)
+	}
+	if got := curDialect.Load().params(998, 3); got != ":p998,:p999,:p1000" {
+		t.Errorf("want ':p998,:p999,:p1000', got '%s'", got)
+	}
+}
+
+func TestAppendParamsAllocs(t *testing.T) {
+	SetDialect(PostgreSQL)
+	buf := make([]byte, 0, 1024)
+
+	for _, start := range []int{1, 990, 5000} {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = curDialect.Load().appendParams(buf[:0], start, 20)
+		})
+		if allocs != 0 {
+			t.Errorf("start %d: want 0 allocs, got %v", start, allocs)
+		}
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	var tests = []struct {
+		in, want string
+	}{
+		{"abc", "abc"},
+		{"100%", `100\%`},
+		{"a_b", `a\_b`},
+		{`c:\temp`, `c:\\temp`},
+		{"[x]", `\[x]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := EscapeLike(tt.in); got != tt.want {
+				t.Errorf("want '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetDialectCache(t *testing.T) {
+	SetDialect(PostgreSQL)
+	first := unsafe.StringData(curDialect.Load().paramCache)
+
+	SetDialect(DefaultDialect)
+	SetDialect(PostgreSQL)
+
+	if unsafe.StringData(curDialect.Load().paramCache) != first {
+		t.Error("param cache regenerated")
+	}
+	if got := curDialect.Load().params(8, 3); got != "$8,$9,$10" {
+		t.Errorf("want '$8,$9,$10', got '%s'", got)
+	}
+}
+
+// TestSetDialectConcurrent switches the dialect while statements are built, run
+// it with -race to check that the dialect state is safe for concurrent use.
+func TestSetDialectConcurrent(t *testing.T) {
+	SetDialect(PostgreSQL)
+	defer SetDialect(DefaultDialect)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDialect(PostgreSQL)
+			SetDialect(MySQL)
+		}()
+		go func() {
+			defer wg.Done()
+			// the dialect may change between clauses, a statement keeps the first one
+			sql, args := From("users").Where("id", 1).WhereIn("role", []any{"a", "b"}).WhereLike("name", "a%").ToSql()
+			if len(args) != 4 {
+				t.Errorf("invalid args: '%v'", args)
+			}
+			pg := sql == `SELECT * FROM users WHERE id=$1 AND role IN ($2,$3) AND name LIKE $4 ESCAPE '\'`
+			my := sql == `SELECT * FROM users WHERE id=? AND role IN (?,?) AND name LIKE ? ESCAPE '\\'`
+			if !pg && !my {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestStatementDialect checks that a statement keeps the dialect of its first
+// clause when the dialect is switched before it is rendered.
+func TestStatementDialect(t *testing.T) {
+	defer SetDialect(DefaultDialect)
+	SetDialect(PostgreSQL)
+
+	q := Update("users").Set("name", "a").Where("id", 1).OrderBy("id").Limit(1).Key("id")
+	SetDialect(SQLServer)
+	q.Where("role", "admin")
+
+	sql, _, err := q.Build()
+	if err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if sql != "UPDATE users SET name=$1 WHERE id IN (SELECT id FROM users WHERE id=$2 AND role=$3 ORDER BY id LIMIT 1)" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+
+	tmpl, err := Compile(q)
+	if err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if tmpl.dialect.kind != kindPostgreSQL {
+		t.Errorf("invalid dialect: %v", tmpl.dialect.kind)
+	}
+}
+
+func TestPositionalPlaceholders(t *testing.T) {
+	SetDialect(MySQL)
+	defer SetDialect(DefaultDialect)
+
+	t.Run("repeated", func(t *testing.T) {
+		sql, args := From("t").Keyset([]Sort{{Column: "a"}, {Column: "b", Desc: true}}, []any{1, 2}).ToSql()
+		if sql != "SELECT * FROM t WHERE (a>? OR (a=? AND b<?)) ORDER BY a,b DESC" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{1, 1, 2}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("append", func(t *testing.T) {
+		buf, args := Delete("t").Where("a", 1).WhereRaw("b<>'?1'").AppendSql([]byte("EXPLAIN ?1 "))
+		if string(buf) != "EXPLAIN ?1 DELETE FROM t WHERE a=? AND b<>'?1'" {
+			t.Errorf("invalid sql: '%s'", buf)
+		}
+		if !reflect.DeepEqual(args, []any{1}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("build", func(t *testing.T) {
+		sql, args, err := Update("t").Set("a", 1).Where("b", 2).OrderBy("c").Limit(1).Build()
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != "UPDATE t SET a=? WHERE b=? ORDER BY c LIMIT 1" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{1, 2}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+}
+
+func TestOrderedPlaceholders(t *testing.T) {
+	SetDialect(SQLite)
+	defer SetDialect(DefaultDialect)
+
+	t.Run("reordered", func(t *testing.T) {
+		sql, args := Update("t").Where("a", 1).Set("b", 2).Set("c", 3).ToSql()
+		if sql != "UPDATE t SET b=@1,c=@2 WHERE a=@3" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{2, 3, 1}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		sql, args := From("t").Keyset([]Sort{{Column: "a"}, {Column: "b", Desc: true}}, []any{1, 2}).ToSql()
+		if sql != "SELECT * FROM t WHERE (a>@1 OR (a=@1 AND b<@2)) ORDER BY a,b DESC" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{1, 2}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("append", func(t *testing.T) {
+		buf, args := Update("t").Where("a", 1).Set("b", 2).WhereRaw("c<>'@1'").AppendSql([]byte("EXPLAIN @9 "))
+		if string(buf) != "EXPLAIN @9 UPDATE t SET b=@1 WHERE a=@2 AND c<>'@1'" {
+			t.Errorf("invalid sql: '%s'", buf)
+		}
+		if !reflect.DeepEqual(args, []any{2, 1}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+}
+
+// legacyParams is the placeholder generation params replaced, kept to compare
+// them in the benchmarks.
+func legacyParams(start int, count int) string {
+	const maxCount = 841
+
+	d := curDialect.Load()
+	end := start + count
+
+	if end >= maxCount {
+		s := make([]string, 0, count)
+		for i := start; i < end; i++ {
+			s = append(s, d.placeholder+strconv.Itoa(i))
+		}
+		return strings.Join(s, ",")
+	}
+	return d.paramCache[legacyIndex(start) : legacyIndex(end)-1]
+}
+
+func legacyIndex(n int) int {
+	if n <= 9 {
+		return 3 * (n - 1)
+	}
+	if n <= 99 {
+		return 3*9 + 4*(n-10)
+	}
+	return 3*9 + 4*90 + 5*(n-100)
+}
+
+func BenchmarkParams(b *testing.B) {
+	SetDialect(PostgreSQL)
+	defer SetDialect(DefaultDialect)
+
+	var sizes = []struct {
+		name         string
+		start, count int
+	}{
+		{"small", 1, 3},
+		{"cached", 500, 20},
+		{"large", 5000, 20},
+	}
+
+	for _, sz := range sizes {
+		b.Run("legacy/"+sz.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				legacyParams(sz.start, sz.count)
+			}
+		})
+		b.Run("params/"+sz.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				curDialect.Load().params(sz.start, sz.count)
+			}
+		})
+		b.Run("appendParams/"+sz.name, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 256)
+			for range b.N {
+				buf = curDialect.Load().appendParams(buf[:0], sz.start, sz.count)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package sqls
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// CursorCodec encodes the sort values of the last row of a page into an opaque,
+// HMAC-signed cursor and decodes them back for the next page.
+type CursorCodec struct {
+	key []byte
+}
+
+// MinCursorKeyLen is the minimum length of the secret key of a CursorCodec.
+const MinCursorKeyLen = 32
+
+// NewCursorCodec creates a cursor codec that signs cursors with the secret key.
+// The key must be random and at least MinCursorKeyLen bytes long, otherwise
+// cursors could be forged, and it returns an error wrapping ErrShortKey.
+func NewCursorCodec(key []byte) (*CursorCodec, error) {
+	if len(key) < MinCursorKeyLen {
+		return nil, fmt.Errorf("%w: %d bytes, at least %d are required", ErrShortKey, len(key), MinCursorKeyLen)
+	}
+	return &CursorCodec{key: slices.Clone(key)}, nil
+}
+
+type cursorPayload struct {
+	Sort   []string      `json:"s"`
+	Values []cursorValue `json:"v"`
+}
+
+// cursorValue keeps the type of a sort value so it decodes to the same Go type.
+type cursorValue struct {
+	Type  string          `json:"t"`
+	Value json.RawMessage `json:"v,omitempty"`
+}
+
+// Encode returns a cursor for the row with the given sort values. Values can be
+// nil, bool, integers, floats, strings, []byte and time.Time.
+func (c *CursorCodec) Encode(sort []Sort, values []any) (string, error) {
+	if len(sort) != len(values) {
+		return "", fmt.Errorf("%w: %d values for %d sort columns", ErrInvalidCursor, len(values), len(sort))
+	}
+
+	payload := cursorPayload{Sort: sortSpec(sort), Values: make([]cursorValue, len(values))}
+	for i, v := range values {
+		cv, err := encodeCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		payload.Values[i] = cv
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append(data, c.sign(data)...)), nil
+}
+
+// Decode verifies the cursor and returns its sort values. It returns an error
+// wrapping ErrInvalidCursor if the cursor is malformed or was tampered with, and
+// ErrCursorMismatch if it was created for a different ordering.
+func (c *CursorCodec) Decode(cursor string, sort []Sort) ([]any, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) < sha256.Size {
+		return nil, ErrInvalidCursor
+	}
+
+	data, mac := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	if !hmac.Equal(mac, c.sign(data)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if !slices.Equal(payload.Sort, sortSpec(sort)) || len(payload.Values) != len(sort) {
+		return nil, ErrCursorMismatch
+	}
+
+	values := make([]any, len(payload.Values))
+	for i, cv := range payload.Values {
+		if values[i], err = decodeCursorValue(cv); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+	return values, nil
+}
+
+// KeysetCursor is like Keyset but takes the sort values from a cursor created by
+// the codec. An empty cursor selects the first page. An invalid cursor selects no
+// rows and makes Build return its error.
+func (s *SelectStmt) KeysetCursor(codec *CursorCodec, sort []Sort, cursor string) *SelectStmt {
+	if cursor == "" {
+		return s.Keyset(sort, nil)
+	}
+
+	after, err := codec.Decode(cursor, sort)
+	if err != nil {
+		if s.invalid == nil {
+			s.invalid = &BuildError{Err: err}
+		}
+		s.where = append(s.where, `1=0`)
+		return s.Keyset(sort, nil)
+	}
+	return s.Keyset(sort, after)
+}
+
+func (c *CursorCodec) sign(data []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func sortSpec(sort []Sort) []string {
+	spec := make([]string, len(sort))
+	for i, o := range sort {
+		spec[i] = o.String()
+	}
+	return spec
+}
+
+func encodeCursorValue(value any) (cursorValue, error) {
+	var typ string
+	var v any
+
+	switch x := value.(type) {
+	case nil:
+		return cursorValue{Type: "n"}, nil
+	case string:
+		typ, v = "s", x
+	case bool:
+		typ, v = "b", x
+	case []byte:
+		typ, v = "x", x
+	case time.Time:
+		typ, v = "t", x.Format(time.RFC3339Nano)
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			typ, v = "i", rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			typ, v = "u", rv.Uint()
+		case reflect.Float32, reflect.Float64:
+			typ, v = "f", rv.Float()
+		default:
+			return cursorValue{}, fmt.Errorf("%w: unsupported value type %T", ErrInvalidCursor, value)
+		}
+	}
+
+	data, err := json.Marshal(v)
+	return cursorValue{Type: typ, Value: data}, err
+}
+
+func decodeCursorValue(cv cursorValue) (any, error) {
+	var err error
+
+	switch cv.Type {
+	case "n":
+		return nil, nil
+	case "s":
+		var v string
+		err = json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "b":
+		var v bool
+		err = json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "x":
+		var v []byte
+		err = json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "t":
+		var v string
+		if err = json.Unmarshal(cv.Value, &v); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, v)
+	case "i":
+		var v int64
+		err = json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "u":
+		var v uint64
+		err = json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "f":
+		var v float64
+		err = json.Unmarshal(cv.Value, &v)
+		return v, err
+	}
+	return nil, fmt.Errorf("unknown value type %q", cv.Type)
+}
@@ -0,0 +1,113 @@
+package sqls
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	codec, err := NewCursorCodec([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort := []Sort{Desc("created_at"), Asc("id")}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	t.Run("round trip", func(t *testing.T) {
+		cursor, err := codec.Encode(sort, []any{created, 42})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := codec.Decode(cursor, sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(values, []any{created, int64(42)}) {
+			t.Errorf("invalid values: %v", values)
+		}
+	})
+
+	t.Run("value types", func(t *testing.T) {
+		sort := []Sort{Asc("a"), Asc("b"), Asc("c"), Asc("d"), Asc("e"), Asc("f")}
+		values := []any{nil, "x", true, []byte("y"), uint8(7), 1.5}
+
+		cursor, err := codec.Encode(sort, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := codec.Decode(cursor, sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, []any{nil, "x", true, []byte("y"), uint64(7), 1.5}) {
+			t.Errorf("invalid values: %v", got)
+		}
+
+		if _, err := codec.Encode([]Sort{Asc("a")}, []any{struct{}{}}); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		cursor, _ := codec.Encode(sort, []any{created, 42})
+
+		other, _ := NewCursorCodec([]byte("fedcba9876543210fedcba9876543210"))
+		if _, err := other.Decode(cursor, sort); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("invalid error: %v", err)
+		}
+		tampered := []byte(cursor)
+		tampered[5] ^= 1
+		if _, err := codec.Decode(string(tampered), sort); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if _, err := codec.Decode("not a cursor", sort); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("different ordering", func(t *testing.T) {
+		cursor, _ := codec.Encode(sort, []any{created, 42})
+
+		if _, err := codec.Decode(cursor, []Sort{Asc("created_at"), Asc("id")}); !errors.Is(err, ErrCursorMismatch) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		cursor, _ := codec.Encode(sort, []any{created, 42})
+
+		sql, args, err := From("events").KeysetCursor(codec, sort, cursor).Limit(10).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sql != `SELECT * FROM events WHERE (created_at<$1 OR (created_at=$1 AND id>$2)) ORDER BY created_at DESC,id LIMIT 10` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{created, int64(42)}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+
+		sql, _, err = From("events").KeysetCursor(codec, sort, "").Limit(10).Build()
+		if err != nil || sql != `SELECT * FROM events ORDER BY created_at DESC,id LIMIT 10` {
+			t.Errorf("invalid first page: '%s' %v", sql, err)
+		}
+
+		_, _, err = From("events").KeysetCursor(codec, []Sort{Asc("id")}, cursor).Build()
+		if !errors.Is(err, ErrCursorMismatch) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
+
+func TestCursorCodecKey(t *testing.T) {
+	for _, key := range [][]byte{nil, {}, []byte("secret")} {
+		if _, err := NewCursorCodec(key); !errors.Is(err, ErrShortKey) {
+			t.Errorf("invalid error: %v", err)
+		}
+	}
+}
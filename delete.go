@@ -1,11 +1,17 @@
 package sqls
 
-import "strings"
+import "slices"
 
 // DeleteStmt represents a SQL DELETE statement.
 type DeleteStmt struct {
 	table string
+	using []string
+	joins []join
 	whereClause
+	orderBy []string
+	limit   int
+	key     string
+	allRows bool
 }
 
 // Delete creates a new DELETE statement.
@@ -21,6 +27,12 @@ func (s *DeleteStmt) Where(column string, value any) *DeleteStmt {
 	return s
 }
 
+// WhereNotEquals adds a WHERE column<>value clause to the DELETE statement.
+func (s *DeleteStmt) WhereNotEquals(column string, value any) *DeleteStmt {
+	s.whereNotEquals(column, value)
+	return s
+}
+
 // WhereNull adds a WHERE column IS NULL clause to the DELETE statement.
 func (s *DeleteStmt) WhereNull(column string) *DeleteStmt {
 	s.whereNull(column)
@@ -33,7 +45,9 @@ func (s *DeleteStmt) WhereNotNull(column string) *DeleteStmt {
 	return s
 }
 
-// WhereExp adds a WHERE column expression value clause to the DELETE statement.
+// WhereExp adds a WHERE column operator value clause to the DELETE statement.
+// The operator must be one of the Op constants, see ParseOp. LIKE operators get
+// the ESCAPE clause of WhereLike.
 func (s *DeleteStmt) WhereExp(column string, ex string, value any) *DeleteStmt {
 	s.whereExp(column, ex, value)
 	return s
@@ -45,19 +59,249 @@ func (s *DeleteStmt) WhereIn(column string, in []any) *DeleteStmt {
 	return s
 }
 
+// WhereNotIn adds a WHERE column NOT IN (values) clause to the DELETE statement.
+// An empty list adds no clause, so it does not scope the statement.
+func (s *DeleteStmt) WhereNotIn(column string, values []any) *DeleteStmt {
+	s.whereNotIn(column, values)
+	return s
+}
+
+// WhereAny adds a WHERE column=ANY(values) clause to the DELETE statement. The slice
+// is bound as a single array argument on PostgreSQL and expanded into a WHERE
+// column IN (values) clause on other dialects.
+func (s *DeleteStmt) WhereAny(column string, values any) *DeleteStmt {
+	s.whereAny(column, values)
+	return s
+}
+
+// WhereBetween adds a WHERE column BETWEEN from AND to clause to the DELETE statement.
+func (s *DeleteStmt) WhereBetween(column string, from any, to any) *DeleteStmt {
+	s.whereBetween(column, ` BETWEEN `, from, to)
+	return s
+}
+
+// WhereNotBetween adds a WHERE column NOT BETWEEN from AND to clause to the DELETE statement.
+func (s *DeleteStmt) WhereNotBetween(column string, from any, to any) *DeleteStmt {
+	s.whereBetween(column, ` NOT BETWEEN `, from, to)
+	return s
+}
+
+// WhereLike adds a WHERE column LIKE value clause to the DELETE statement.
+// The % and _ wildcards in value are escaped so it matches literally, use
+// WhereLikePattern for a pattern.
+func (s *DeleteStmt) WhereLike(column string, value string) *DeleteStmt {
+	s.whereLike(column, ` LIKE `, EscapeLike(value))
+	return s
+}
+
+// WhereNotLike adds a WHERE column NOT LIKE value clause to the DELETE statement.
+// The value is escaped, see WhereLike.
+func (s *DeleteStmt) WhereNotLike(column string, value string) *DeleteStmt {
+	s.whereLike(column, ` NOT LIKE `, EscapeLike(value))
+	return s
+}
+
+// WhereILike adds a case-insensitive WHERE column ILIKE value clause to the DELETE
+// statement. Dialects without ILIKE compare LOWER(column) and LOWER(value).
+// The value is escaped, see WhereLike.
+func (s *DeleteStmt) WhereILike(column string, value string) *DeleteStmt {
+	s.whereILike(column, ILike, EscapeLike(value))
+	return s
+}
+
+// WhereLikePattern adds a WHERE column LIKE pattern clause to the DELETE statement.
+// The pattern is used as is, so % and _ in it are wildcards: build it with
+// Contains, HasPrefix, HasSuffix or EscapeLike to match user input literally.
+func (s *DeleteStmt) WhereLikePattern(column string, pattern string) *DeleteStmt {
+	s.whereLike(column, ` LIKE `, pattern)
+	return s
+}
+
+// WhereNotLikePattern adds a WHERE column NOT LIKE pattern clause to the DELETE
+// statement. The pattern is used as is, see WhereLikePattern.
+func (s *DeleteStmt) WhereNotLikePattern(column string, pattern string) *DeleteStmt {
+	s.whereLike(column, ` NOT LIKE `, pattern)
+	return s
+}
+
+// WhereILikePattern adds a case-insensitive WHERE column ILIKE pattern clause to
+// the DELETE statement. The pattern is used as is, see WhereLikePattern.
+func (s *DeleteStmt) WhereILikePattern(column string, pattern string) *DeleteStmt {
+	s.whereILike(column, ILike, pattern)
+	return s
+}
+
+// WhereRow adds a WHERE (columns) operator (values) row value comparison to the DELETE
+// statement, such as (created_at,id)>($1,$2). The operator must be =, <>, <, <=, > or >=.
+func (s *DeleteStmt) WhereRow(columns []string, ex string, values []any) *DeleteStmt {
+	s.whereRow(columns, ex, values)
+	return s
+}
+
 // WhereRaw adds a raw WHERE clause to the DELETE statement.
 func (s *DeleteStmt) WhereRaw(raw string) *DeleteStmt {
 	s.whereRaw(raw)
 	return s
 }
 
+// Using adds tables to the USING clause of the DELETE statement.
+func (s *DeleteStmt) Using(tables ...string) *DeleteStmt {
+	s.using = append(s.using, tables...)
+	return s
+}
+
+// Join adds a JOIN table ON on1=on2 clause to the DELETE statement.
+func (s *DeleteStmt) Join(table string, on1 string, on2 string) *DeleteStmt {
+	s.joins = append(s.joins, join{table: table, on: []string{on1 + "=" + on2}})
+	return s
+}
+
+// On adds a column=value condition to the last JOIN clause of the DELETE statement.
+func (s *DeleteStmt) On(column string, value any) *DeleteStmt {
+	s.joinOn(s.joins, column, value)
+	return s
+}
+
+// OrderBy adds an ORDER BY clause to the DELETE statement.
+func (s *DeleteStmt) OrderBy(columns ...string) *DeleteStmt {
+	s.orderBy = columns
+	return s
+}
+
+// Limit adds a LIMIT clause to the DELETE statement.
+func (s *DeleteStmt) Limit(limit int) *DeleteStmt {
+	s.limit = limit
+	return s
+}
+
+// Key sets the key column of the table. PostgreSQL has no ORDER BY and LIMIT in
+// DELETE, so they are emulated by matching the key to a subquery:
+// WHERE key IN (SELECT key FROM table WHERE ... ORDER BY ... LIMIT n).
+func (s *DeleteStmt) Key(column string) *DeleteStmt {
+	s.key = column
+	return s
+}
+
+// AllRows allows the DELETE statement to be built without a WHERE clause.
+func (s *DeleteStmt) AllRows() *DeleteStmt {
+	s.allRows = true
+	return s
+}
+
+// Clone returns a deep copy of the DELETE statement, so a base statement can be
+// extended without affecting it or other statements cloned from it.
+func (s *DeleteStmt) Clone() *DeleteStmt {
+	c := *s
+	c.using = slices.Clone(s.using)
+	c.joins = cloneJoins(s.joins)
+	c.whereClause = s.whereClause.clone()
+	c.orderBy = slices.Clone(s.orderBy)
+	return &c
+}
+
 // ToSql generates the SQL DELETE statement and returns it along with any arguments.
+// It does not validate the statement nor apply the unscoped policy, use Build or Exec for that.
 func (s *DeleteStmt) ToSql() (string, []any) {
-	query := "DELETE FROM " + s.table
+	return render(s.renderDialect(), s.appendSql, s.args)
+}
 
-	if s.where != nil {
-		query += " WHERE " + strings.Join(s.where, " AND ")
+// AppendSql appends the SQL DELETE statement to buf and returns the extended
+// buffer along with any arguments.
+func (s *DeleteStmt) AppendSql(buf []byte) ([]byte, []any) {
+	d := s.renderDialect()
+	return d.bindOrder(s.appendSql(buf, d), len(buf), s.args)
+}
+
+// Build generates the SQL DELETE statement and returns it along with any arguments.
+// It returns a *BuildError if the statement is invalid or cannot be rendered for the
+// current dialect, or an error wrapping ErrUnscoped if it has no WHERE clause and is
+// not allowed by the unscoped policy.
+func (s *DeleteStmt) Build() (string, []any, error) {
+	if s.table == "" {
+		return "", nil, &BuildError{Statement: "DELETE", Err: ErrNoTable}
+	}
+	if err := s.check("DELETE"); err != nil {
+		return "", nil, err
+	}
+	if s.limit < 0 {
+		return "", nil, &BuildError{Statement: "DELETE", Err: ErrNegativeLimit}
+	}
+	if err := s.checkScope(s.table, s.allRows); err != nil {
+		return "", nil, err
 	}
+	d := s.renderDialect()
+	if err := s.checkOrderLimit(d); err != nil {
+		return "", nil, err
+	}
+
+	query, args := render(d, s.appendSql, s.args)
+	return query, args, nil
+}
 
-	return query, s.args
+// checkOrderLimit returns a *BuildError if the ORDER BY and LIMIT of the
+// statement cannot be rendered for the dialect.
+func (s *DeleteStmt) checkOrderLimit(d *Dialect) error {
+	if s.orderBy == nil && s.limit <= 0 {
+		return nil
+	}
+	return checkOrderLimit(d, "DELETE", s.key, s.using != nil || s.joins != nil)
+}
+
+func (s *DeleteStmt) appendSql(b []byte, d *Dialect) []byte {
+	if s.orderBy == nil && s.limit <= 0 {
+		return s.appendJoinSql(b, d)
+	}
+
+	if d.kind == kindPostgreSQL && s.key != "" && s.using == nil && s.joins == nil {
+		// DELETE FROM t WHERE id IN (SELECT id FROM t WHERE ... ORDER BY ... LIMIT n)
+		b = append(b, "DELETE FROM "...)
+		b = append(b, s.table...)
+		b = append(b, " WHERE "...)
+		b = append(b, s.key...)
+		b = append(b, " IN (SELECT "...)
+		b = append(b, s.key...)
+		b = append(b, " FROM "...)
+		b = append(b, s.table...)
+		b = appendWhere(b, s.where)
+		b = appendOrderLimit(b, s.orderBy, s.limit)
+		return append(b, ')')
+	}
+	// rendered natively, Build reports the dialects that do not support it
+	b = s.appendJoinSql(b, d)
+	return appendOrderLimit(b, s.orderBy, s.limit)
+}
+
+func (s *DeleteStmt) appendJoinSql(b []byte, d *Dialect) []byte {
+	if s.using == nil && s.joins == nil {
+		b = append(b, "DELETE FROM "...)
+		b = append(b, s.table...)
+		return appendWhere(b, s.where)
+	}
+
+	switch d.kind {
+	case kindMySQL, kindSQLServer:
+		// DELETE t FROM t JOIN x ON ... WHERE ...
+		b = append(b, "DELETE "...)
+		b = append(b, tableAlias(s.table)...)
+		b = append(b, " FROM "...)
+		b = appendTables(b, s.table, s.using, s.joins)
+		return appendWhere(b, s.where)
+	case kindSQLite:
+		// SQLite has no multi-table DELETE, select the rows to delete by rowid instead.
+		b = append(b, "DELETE FROM "...)
+		b = append(b, tableName(s.table)...)
+		b = append(b, " WHERE rowid IN (SELECT "...)
+		b = append(b, tableAlias(s.table)...)
+		b = append(b, ".rowid FROM "...)
+		b = appendTables(b, s.table, s.using, s.joins)
+		b = appendWhere(b, s.where)
+		return append(b, ')')
+	default:
+		// DELETE FROM t USING x WHERE ...
+		b = append(b, "DELETE FROM "...)
+		b = append(b, s.table...)
+		b = append(b, " USING "...)
+		b, conds := appendFrom(b, s.using, s.joins)
+		return appendWhere(b, conds, s.where)
+	}
 }
@@ -1,6 +1,8 @@
 package sqls
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -41,3 +43,228 @@ func TestDelete(t *testing.T) {
 	})
 
 }
+
+func TestDeleteJoin(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{"postgres", PostgreSQL, "DELETE FROM orders o USING customers c WHERE o.customer_id=c.id AND c.region=$1 AND c.active=$2"},
+		{"mysql", MySQL, "DELETE o FROM orders o JOIN customers c ON o.customer_id=c.id AND c.region=? WHERE c.active=?"},
+		{"sql server", SQLServer, "DELETE o FROM orders o JOIN customers c ON o.customer_id=c.id AND c.region=@1 WHERE c.active=@2"},
+		{"sqlite", SQLite, "DELETE FROM orders WHERE rowid IN (SELECT o.rowid FROM orders o JOIN customers c ON o.customer_id=c.id AND c.region=@1 WHERE c.active=@2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args := Delete("orders o").
+				Join("customers c", "o.customer_id", "c.id").
+				On("c.region", "EU").
+				Where("c.active", false).
+				ToSql()
+
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+			if !reflect.DeepEqual(args, []any{"EU", false}) {
+				t.Errorf("invalid args: '%v'", args)
+			}
+		})
+	}
+
+	t.Run("Delete using", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args := Delete("orders").
+			Using("customers").
+			WhereRaw("orders.customer_id=customers.id").
+			Where("customers.banned", true).
+			ToSql()
+
+		if sql != "DELETE FROM orders USING customers WHERE orders.customer_id=customers.id AND customers.banned=$1" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{true}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+}
+
+func TestDeleteLimit(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		key     string
+		want    string
+		err     error
+	}{
+		{"mysql", MySQL, "", "DELETE FROM logs WHERE level=? ORDER BY created_at LIMIT 1000", nil},
+		{"sqlite", SQLite, "", "DELETE FROM logs WHERE level=@1 ORDER BY created_at LIMIT 1000", nil},
+		{"postgres", PostgreSQL, "id", "DELETE FROM logs WHERE id IN (SELECT id FROM logs WHERE level=$1 ORDER BY created_at LIMIT 1000)", nil},
+		{"postgres without key", PostgreSQL, "", "", ErrNoKey},
+		{"sql server", SQLServer, "id", "", ErrNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args, err := Delete("logs").
+				Where("level", "debug").
+				OrderBy("created_at").
+				Limit(1000).
+				Key(tt.key).
+				Build()
+
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+			if tt.err == nil && !reflect.DeepEqual(args, []any{"debug"}) {
+				t.Errorf("invalid args: '%v'", args)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("invalid error: %v", err)
+			}
+		})
+	}
+
+	t.Run("Delete limit not supported", func(t *testing.T) {
+		SetDialect(SQLServer)
+
+		stmt := Delete("logs").Where("level", "debug").Limit(1000)
+		db, tdb := openTestDB(t, nil)
+		if _, err := stmt.Exec(context.Background(), db); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if len(tdb.queries) != 0 {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("Delete two joins", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args := Delete("orders o").
+			Join("customers c", "o.customer_id", "c.id").
+			Join("items i", "i.order_id", "o.id").
+			Where("c.banned", true).
+			ToSql()
+
+		if sql != "DELETE FROM orders o USING customers c,items i WHERE o.customer_id=c.id AND i.order_id=o.id AND c.banned=$1" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{true}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("Delete limit with join", func(t *testing.T) {
+		SetDialect(MySQL)
+
+		sql, _, err := Delete("logs l").
+			Join("users u", "l.user_id", "u.id").
+			Where("u.banned", true).
+			Limit(10).
+			Build()
+
+		if sql != "" || !errors.Is(err, ErrNotSupported) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
+
+func TestDeleteUnscoped(t *testing.T) {
+	SetDialect(PostgreSQL)
+	defer SetUnscopedPolicy(RequireAllRows)
+
+	t.Run("Delete without where", func(t *testing.T) {
+		sql, args, err := Delete("users").Build()
+
+		if !errors.Is(err, ErrUnscoped) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != "" || args != nil {
+			t.Errorf("invalid sql: '%s' %v", sql, args)
+		}
+
+		sql, _ = Delete("users").ToSql()
+		if sql != "DELETE FROM users" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+	})
+
+	t.Run("Delete all rows", func(t *testing.T) {
+		sql, _, err := Delete("users").AllRows().Build()
+
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != "DELETE FROM users" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+	})
+
+	t.Run("Delete policy", func(t *testing.T) {
+		SetUnscopedPolicy(DenyUnscoped)
+		if _, _, err := Delete("users").AllRows().Build(); !errors.Is(err, ErrUnscoped) {
+			t.Errorf("invalid error: %v", err)
+		}
+
+		SetUnscopedPolicy(AllowUnscoped)
+		if _, _, err := Delete("users").Build(); err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+
+		// nil restores the default policy
+		SetUnscopedPolicy(nil)
+		if _, _, err := Delete("users").Build(); !errors.Is(err, ErrUnscoped) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("Delete with empty not in", func(t *testing.T) {
+		if _, _, err := Delete("users").WhereNotIn("id", nil).Build(); !errors.Is(err, ErrUnscoped) {
+			t.Errorf("invalid error: %v", err)
+		}
+
+		sql, args, err := Delete("users").WhereNotIn("id", []any{}).Where("tenant", 7).Build()
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != "DELETE FROM users WHERE tenant=$1" || !reflect.DeepEqual(args, []any{7}) {
+			t.Errorf("invalid sql: '%s' %v", sql, args)
+		}
+	})
+
+	t.Run("Delete with limit", func(t *testing.T) {
+		SetDialect(MySQL)
+		defer SetDialect(PostgreSQL)
+
+		if _, _, err := Delete("users").Limit(1 << 30).Build(); !errors.Is(err, ErrUnscoped) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if _, _, err := Delete("users").OrderBy("id").Limit(10).AllRows().Build(); err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
+
+func TestDeletePredicates(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	sql, args := Delete("sessions").
+		WhereNotEquals("user_id", 1).
+		WhereBetween("created", 10, 20).
+		WhereLikePattern("token", HasPrefix("tmp_")).
+		ToSql()
+
+	if sql != `DELETE FROM sessions WHERE user_id<>$1 AND created BETWEEN $2 AND $3 AND token LIKE $4 ESCAPE '\'` {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{1, 10, 20, `tmp\_%`}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+}
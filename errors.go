@@ -0,0 +1,74 @@
+package sqls
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotSupported is returned when a statement cannot be rendered for the current dialect.
+	ErrNotSupported = errors.New("sqls: not supported by dialect")
+	// ErrUnscoped is returned when an UPDATE or DELETE statement has no WHERE clause.
+	ErrUnscoped = errors.New("sqls: UPDATE or DELETE without WHERE")
+	// ErrNoTable is returned when a statement has no table.
+	ErrNoTable = errors.New("sqls: no table")
+	// ErrNoColumns is returned when an INSERT or UPDATE statement has no columns.
+	ErrNoColumns = errors.New("sqls: no columns")
+	// ErrNoKey is returned when a statement needs a key column and has none.
+	ErrNoKey = errors.New("sqls: no key column")
+	// ErrNoTypes is returned when an UPDATE statement for many rows has no column types on PostgreSQL.
+	ErrNoTypes = errors.New("sqls: no column types")
+	// ErrNoRows is returned when a statement for many rows has no rows.
+	ErrNoRows = errors.New("sqls: no rows")
+	// ErrValueCount is returned when a row does not have one value per column.
+	ErrValueCount = errors.New("sqls: number of values does not match the columns")
+	// ErrInvalidOp is returned for an unknown comparison operator.
+	ErrInvalidOp = errors.New("sqls: invalid operator")
+	// ErrInvalidCursor is returned for a malformed or tampered pagination cursor.
+	ErrInvalidCursor = errors.New("sqls: invalid cursor")
+	// ErrShortKey is returned for a cursor signing key shorter than MinCursorKeyLen.
+	ErrShortKey = errors.New("sqls: cursor key too short")
+	// ErrCursorMismatch is returned for a pagination cursor created for a different ordering.
+	ErrCursorMismatch = errors.New("sqls: cursor does not match the ordering")
+	// ErrNegativeLimit is returned when a LIMIT is negative.
+	ErrNegativeLimit = errors.New("sqls: negative LIMIT")
+	// ErrNegativeOffset is returned when an OFFSET is negative.
+	ErrNegativeOffset = errors.New("sqls: negative OFFSET")
+	// ErrMissingArg is returned when a template is bound without a value for one of its slots.
+	ErrMissingArg = errors.New("sqls: missing template argument")
+	// ErrNoField is returned when a result column has no matching struct field.
+	ErrNoField = errors.New("sqls: no struct field for column")
+	// ErrInvalidArg is returned for a template slot that cannot be bound, such as an empty list.
+	ErrInvalidArg = errors.New("sqls: invalid template argument")
+)
+
+// BuildError is returned by Build when a statement is invalid.
+// Use errors.Is with one of the Err variables to check the reason.
+type BuildError struct {
+	Statement string // SELECT, INSERT, UPDATE or DELETE
+	Err       error
+	Detail    string
+}
+
+func (e *BuildError) Error() string {
+	msg := e.Err.Error() + " in " + e.Statement
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// in returns a copy of the error for the given statement.
+func (e *BuildError) in(statement string) *BuildError {
+	err := *e
+	err.Statement = statement
+	return &err
+}
+
+func valueCountError(row int, values int, columns int) *BuildError {
+	return &BuildError{Err: ErrValueCount, Detail: fmt.Sprintf("row %d has %d values for %d columns", row, values, columns)}
+}
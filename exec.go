@@ -0,0 +1,247 @@
+package sqls
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor runs SQL statements. It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Row is the result of QueryRow. Its Scan returns the error of Build if the
+// statement is invalid, otherwise it is the same as sql.Row.
+type Row struct {
+	row *sql.Row
+	err error
+}
+
+// Scan copies the columns of the row into dest, see sql.Row.Scan.
+func (r *Row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Scan(dest...)
+}
+
+// Err returns the error of Build or of running the query, see sql.Row.Err.
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Err()
+}
+
+func exec(ctx context.Context, db Executor, stmt Statement) (sql.Result, error) {
+	query, args, err := stmt.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := TraceQuery(ctx, query, args)
+	res, err := db.ExecContext(ctx, query, args...)
+	rows := int64(-1)
+	if err == nil {
+		if n, rerr := res.RowsAffected(); rerr == nil {
+			rows = n
+		}
+	}
+	done(rows, err)
+	return res, err
+}
+
+// chunksResult is the result of a statement executed in chunks.
+type chunksResult struct {
+	last sql.Result
+	rows int64
+	err  error
+}
+
+// LastInsertId returns the id of the last chunk, see sql.Result.
+func (r *chunksResult) LastInsertId() (int64, error) {
+	return r.last.LastInsertId()
+}
+
+// RowsAffected returns the rows affected by all the chunks, see sql.Result.
+func (r *chunksResult) RowsAffected() (int64, error) {
+	return r.rows, r.err
+}
+
+// execChunks executes the chunks of a statement one after the other. It stops
+// at the first error, the chunks executed before it are not rolled back.
+func execChunks[S Statement](ctx context.Context, db Executor, chunks []S) (sql.Result, error) {
+	if len(chunks) == 1 {
+		return exec(ctx, db, chunks[0])
+	}
+
+	res := &chunksResult{}
+	for _, chunk := range chunks {
+		r, err := exec(ctx, db, chunk)
+		if err != nil {
+			return nil, err
+		}
+		res.last = r
+		if res.err == nil {
+			var n int64
+			n, res.err = r.RowsAffected()
+			res.rows += n
+		}
+	}
+	return res, nil
+}
+
+func query(ctx context.Context, db Executor, stmt Statement) (*sql.Rows, error) {
+	query, args, err := stmt.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := TraceQuery(ctx, query, args)
+	rows, err := db.QueryContext(ctx, query, args...)
+	done(-1, err)
+	return rows, err
+}
+
+func queryRow(ctx context.Context, db Executor, stmt Statement) *Row {
+	query, args, err := stmt.Build()
+	if err != nil {
+		return &Row{err: err}
+	}
+
+	ctx, done := TraceQuery(ctx, query, args)
+	row := db.QueryRowContext(ctx, query, args...)
+	done(-1, row.Err())
+	return &Row{row: row}
+}
+
+// Exec builds the statement and executes it with db.
+func (s *SelectStmt) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, s)
+}
+
+// Query builds the statement and runs it with db.
+func (s *SelectStmt) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, s)
+}
+
+// QueryRow builds the statement and runs it with db, expecting at most one row.
+func (s *SelectStmt) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, s)
+}
+
+// Exec builds the statement and executes it with db.
+func (s *InsertStmt) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, s)
+}
+
+// Query builds the statement and runs it with db, use it with Returning.
+func (s *InsertStmt) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, s)
+}
+
+// QueryRow builds the statement and runs it with db, use it with Returning.
+func (s *InsertStmt) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, s)
+}
+
+// Exec builds the statement and executes it with db. A statement above the
+// parameter limit of the dialect is executed in chunks, see Chunks; run it in a
+// transaction to insert all the rows or none.
+func (s *InsertManyStmt) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return execChunks(ctx, db, s.Chunks())
+}
+
+// Query builds the statement and runs it with db, use it with Returning.
+func (s *InsertManyStmt) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, s)
+}
+
+// QueryRow builds the statement and runs it with db, use it with Returning.
+func (s *InsertManyStmt) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, s)
+}
+
+// Exec builds the statement and executes it with db.
+func (s *UpdateStmt) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, s)
+}
+
+// Query builds the statement and runs it with db.
+func (s *UpdateStmt) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, s)
+}
+
+// QueryRow builds the statement and runs it with db, expecting at most one row.
+func (s *UpdateStmt) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, s)
+}
+
+// Exec builds the statement and executes it with db. A statement above the
+// parameter limit of the dialect is executed in chunks, see Chunks; run it in a
+// transaction to update all the rows or none.
+func (s *UpdateManyStmt) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return execChunks(ctx, db, s.Chunks())
+}
+
+// Exec builds the statement and executes it with db.
+func (s *DeleteStmt) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, s)
+}
+
+// Query builds the statement and runs it with db.
+func (s *DeleteStmt) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, s)
+}
+
+// QueryRow builds the statement and runs it with db, expecting at most one row.
+func (s *DeleteStmt) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, s)
+}
+
+// Exec builds the query and executes it with db.
+func (q SelectQuery) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, q)
+}
+
+// Query builds the query and runs it with db.
+func (q SelectQuery) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, q)
+}
+
+// QueryRow builds the query and runs it with db, expecting at most one row.
+func (q SelectQuery) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, q)
+}
+
+// Exec builds the query and executes it with db.
+func (q UpdateQuery) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, q)
+}
+
+// Query builds the query and runs it with db.
+func (q UpdateQuery) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, q)
+}
+
+// QueryRow builds the query and runs it with db, expecting at most one row.
+func (q UpdateQuery) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, q)
+}
+
+// Exec builds the query and executes it with db.
+func (q DeleteQuery) Exec(ctx context.Context, db Executor) (sql.Result, error) {
+	return exec(ctx, db, q)
+}
+
+// Query builds the query and runs it with db.
+func (q DeleteQuery) Query(ctx context.Context, db Executor) (*sql.Rows, error) {
+	return query(ctx, db, q)
+}
+
+// QueryRow builds the query and runs it with db, expecting at most one row.
+func (q DeleteQuery) QueryRow(ctx context.Context, db Executor) *Row {
+	return queryRow(ctx, db, q)
+}
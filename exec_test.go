@@ -0,0 +1,299 @@
+package sqls
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// testDB is a database of the sqlstest driver. It records the statements run
+// against it and returns its rows for every query.
+type testDB struct {
+	mu      sync.Mutex
+	queries []string
+	args    [][]any
+	columns []string
+	rows    [][]driver.Value
+	errs    map[string]error // returned by Exec for the queries
+
+	prepared int
+	closed   int
+}
+
+var testDBs sync.Map
+
+func init() {
+	sql.Register("sqlstest", testDriver{})
+}
+
+// openTestDB opens a database that returns the rows with the columns for every query.
+func openTestDB(t testing.TB, columns []string, rows ...[]driver.Value) (*sql.DB, *testDB) {
+	tdb := &testDB{columns: columns, rows: rows}
+	testDBs.Store(t.Name(), tdb)
+
+	db, err := sql.Open("sqlstest", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		testDBs.Delete(t.Name())
+	})
+	return db, tdb
+}
+
+func (db *testDB) record(query string, args []driver.Value) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	list := make([]any, len(args))
+	for i, a := range args {
+		list[i] = a
+	}
+	db.queries = append(db.queries, query)
+	db.args = append(db.args, list)
+}
+
+func (db *testDB) last() (string, []any) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if len(db.queries) == 0 {
+		return "", nil
+	}
+	return db.queries[len(db.queries)-1], db.args[len(db.args)-1]
+}
+
+type testDriver struct{}
+
+func (testDriver) Open(name string) (driver.Conn, error) {
+	db, ok := testDBs.Load(name)
+	if !ok {
+		return nil, errors.New("unknown test database " + name)
+	}
+	return &testConn{db: db.(*testDB)}, nil
+}
+
+type testConn struct {
+	db *testDB
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.prepared++
+	return &testStmt{db: c.db, query: query}, nil
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	c.db.record("BEGIN", nil)
+	return &testTx{db: c.db}, nil
+}
+
+type testTx struct {
+	db *testDB
+}
+
+func (tx *testTx) Commit() error {
+	tx.db.record("COMMIT", nil)
+	return nil
+}
+
+func (tx *testTx) Rollback() error {
+	tx.db.record("ROLLBACK", nil)
+	return nil
+}
+
+type testStmt struct {
+	db    *testDB
+	query string
+}
+
+func (s *testStmt) Close() error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.closed++
+	return nil
+}
+
+func (s *testStmt) NumInput() int {
+	return -1
+}
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.record(s.query, args)
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if err := s.db.errs[s.query]; err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(len(s.db.rows)), nil
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.record(s.query, args)
+	return &testRows{columns: s.db.columns, rows: s.db.rows}, nil
+}
+
+type testRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *testRows) Columns() []string {
+	return r.columns
+}
+
+func (r *testRows) Close() error {
+	return nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestExec(t *testing.T) {
+	SetDialect(PostgreSQL)
+	ctx := context.Background()
+
+	t.Run("exec", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		_, err := Update("users").Set("active", false).Where("id", 7).Exec(ctx, db)
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		query, args := tdb.last()
+		if query != "UPDATE users SET active=$1 WHERE id=$2" {
+			t.Errorf("invalid sql: '%s'", query)
+		}
+		if !reflect.DeepEqual(args, []any{false, int64(7)}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		db, tdb := openTestDB(t, []string{"id", "name"}, []driver.Value{int64(1), "John"}, []driver.Value{int64(2), "Jane"})
+
+		rows, err := From("users").Select("id", "name").Where("active", true).Query(ctx, db)
+		if err != nil {
+			t.Fatalf("invalid error: %v", err)
+		}
+		defer rows.Close()
+
+		var names []string
+		for rows.Next() {
+			var id int
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, name)
+		}
+		if !reflect.DeepEqual(names, []string{"John", "Jane"}) {
+			t.Errorf("invalid rows: '%v'", names)
+		}
+		if query, _ := tdb.last(); query != "SELECT id,name FROM users WHERE active=$1" {
+			t.Errorf("invalid sql: '%s'", query)
+		}
+	})
+
+	t.Run("query row", func(t *testing.T) {
+		db, tdb := openTestDB(t, []string{"id"}, []driver.Value{int64(42)})
+
+		var id int
+		err := Insert("users").Set("name", "John").Returning("id").QueryRow(ctx, db).Scan(&id)
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if id != 42 {
+			t.Errorf("invalid id: %d", id)
+		}
+		if query, _ := tdb.last(); query != "INSERT INTO users (name) VALUES ($1) RETURNING id" {
+			t.Errorf("invalid sql: '%s'", query)
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Delete("sessions").Where("user_id", 7).Exec(ctx, tx); err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		tx.Commit()
+
+		if !reflect.DeepEqual(tdb.queries, []string{"BEGIN", "DELETE FROM sessions WHERE user_id=$1", "COMMIT"}) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("exec in chunks", func(t *testing.T) {
+		SetDialect(Dialect{placeholder: "?", kind: kindSQLite, maxParams: 4})
+		defer SetDialect(PostgreSQL)
+		db, tdb := openTestDB(t, nil, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+
+		stmt := UpdateMany("users").Key("id").Columns("name")
+		for i := range 5 {
+			stmt.Values(i, "John")
+		}
+		res, err := stmt.Exec(ctx, db)
+		if err != nil {
+			t.Fatalf("invalid error: %v", err)
+		}
+		if n, _ := res.RowsAffected(); n != 6 {
+			t.Errorf("invalid rows affected: %d", n)
+		}
+		if len(tdb.queries) != 3 {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+		if query, args := tdb.last(); query != "UPDATE users SET name=CASE id WHEN ?1 THEN ?2 END WHERE id IN (?1)" ||
+			!reflect.DeepEqual(args, []any{int64(4), "John"}) {
+			t.Errorf("invalid sql: '%s' '%v'", query, args)
+		}
+
+		if _, err := InsertMany("users").Columns("id").Values(1).Values(2, 3).Exec(ctx, db); !errors.Is(err, ErrValueCount) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if len(tdb.queries) != 3 {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("invalid statement", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		if _, err := Delete("users").Exec(ctx, db); !errors.Is(err, ErrUnscoped) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if _, err := From("").Query(ctx, db); !errors.Is(err, ErrNoTable) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if err := Update("users").QueryRow(ctx, db).Scan(); !errors.Is(err, ErrNoColumns) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if len(tdb.queries) != 0 {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package sqls
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// QueryInfo describes a query run by the execution helpers.
+type QueryInfo struct {
+	SQL   string
+	Args  []any
+	Start time.Time
+
+	// set before AfterQuery
+	Duration     time.Duration
+	RowsAffected int64 // -1 if unknown, such as for queries returning rows
+	Err          error
+}
+
+// Hook is called around every query run by the execution helpers. The
+// context returned by BeforeQuery is used for the query and AfterQuery.
+type Hook interface {
+	BeforeQuery(ctx context.Context, q *QueryInfo) context.Context
+	AfterQuery(ctx context.Context, q *QueryInfo)
+}
+
+var hooks atomic.Pointer[[]Hook]
+
+// SetHooks sets the hooks called around every query run by Exec, Query,
+// QueryRow, All and One. BeforeQuery is called in order and AfterQuery in
+// reverse order. It is safe to call while queries run in other goroutines.
+func SetHooks(h ...Hook) {
+	if len(h) == 0 {
+		hooks.Store(nil)
+		return
+	}
+	hooks.Store(&h)
+}
+
+func noopDone(int64, error) {}
+
+// TraceQuery calls the BeforeQuery hooks for a query and returns the context
+// to run it with and a function that calls the AfterQuery hooks when it is
+// done. It is used by the execution helpers, and can be used to report
+// queries run in other ways to the hooks.
+func TraceQuery(ctx context.Context, sql string, args []any) (context.Context, func(rowsAffected int64, err error)) {
+	hp := hooks.Load()
+	if hp == nil {
+		return ctx, noopDone
+	}
+
+	h := *hp
+	q := &QueryInfo{SQL: sql, Args: args, Start: time.Now(), RowsAffected: -1}
+	for _, hook := range h {
+		ctx = hook.BeforeQuery(ctx, q)
+	}
+
+	return ctx, func(rowsAffected int64, err error) {
+		q.Duration = time.Since(q.Start)
+		q.RowsAffected = rowsAffected
+		q.Err = err
+		for i := len(h) - 1; i >= 0; i-- {
+			h[i].AfterQuery(ctx, q)
+		}
+	}
+}
+
+// SlogHook logs every query with its arguments, duration, rows affected and
+// error. Queries that fail are logged at slog.LevelError.
+type SlogHook struct {
+	Logger   *slog.Logger // nil means slog.Default()
+	Level    slog.Level   // the level of queries that succeed
+	Redactor *Redactor    // replaces sensitive arguments, if not nil
+}
+
+func (h *SlogHook) BeforeQuery(ctx context.Context, q *QueryInfo) context.Context {
+	return ctx
+}
+
+func (h *SlogHook) AfterQuery(ctx context.Context, q *QueryInfo) {
+	logger := h.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	level := h.Level
+	if q.Err != nil {
+		level = slog.LevelError
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	args := q.Args
+	if h.Redactor != nil {
+		args = h.Redactor.Args(q.SQL, args)
+	}
+	attrs := []slog.Attr{
+		slog.String("sql", q.SQL),
+		slog.Any("args", args),
+		slog.Duration("duration", q.Duration),
+	}
+	if q.RowsAffected >= 0 {
+		attrs = append(attrs, slog.Int64("rows", q.RowsAffected))
+	}
+	if q.Err != nil {
+		attrs = append(attrs, slog.Any("error", q.Err))
+	}
+	logger.LogAttrs(ctx, level, "query", attrs...)
+}
+
+// Tracer starts spans, wrap the tracing library in use to implement it.
+type Tracer interface {
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+// TraceHook starts a span for every query, named after its first keyword such
+// as "sqls SELECT", with the db.statement and db.rows_affected attributes.
+// Arguments are not added to the span.
+type TraceHook struct {
+	Tracer Tracer
+}
+
+type spanKey struct {
+	h *TraceHook
+}
+
+func (h *TraceHook) BeforeQuery(ctx context.Context, q *QueryInfo) context.Context {
+	verb, _, _ := strings.Cut(q.SQL, " ")
+	ctx, span := h.Tracer.StartSpan(ctx, "sqls "+verb)
+	span.SetAttribute("db.statement", q.SQL)
+	return context.WithValue(ctx, spanKey{h}, span)
+}
+
+func (h *TraceHook) AfterQuery(ctx context.Context, q *QueryInfo) {
+	span, ok := ctx.Value(spanKey{h}).(Span)
+	if !ok {
+		return
+	}
+	if q.RowsAffected >= 0 {
+		span.SetAttribute("db.rows_affected", q.RowsAffected)
+	}
+	if q.Err != nil {
+		span.RecordError(q.Err)
+	}
+	span.End()
+}
@@ -0,0 +1,147 @@
+package sqls
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type recordHook struct {
+	name  string
+	calls *[]string
+	infos []QueryInfo
+}
+
+type hookKey struct{}
+
+func (h *recordHook) BeforeQuery(ctx context.Context, q *QueryInfo) context.Context {
+	*h.calls = append(*h.calls, "before "+h.name)
+	return context.WithValue(ctx, hookKey{}, h.name)
+}
+
+func (h *recordHook) AfterQuery(ctx context.Context, q *QueryInfo) {
+	*h.calls = append(*h.calls, "after "+h.name+" "+ctx.Value(hookKey{}).(string))
+	h.infos = append(h.infos, *q)
+}
+
+func TestHooks(t *testing.T) {
+	SetDialect(PostgreSQL)
+	defer SetHooks()
+	ctx := context.Background()
+
+	var calls []string
+	a := &recordHook{name: "a", calls: &calls}
+	b := &recordHook{name: "b", calls: &calls}
+	SetHooks(a, b)
+
+	db, _ := openTestDB(t, []string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+	if _, err := Update("users").Set("active", false).Where("id", 7).Exec(ctx, db); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, err := All[int](ctx, db, From("users").Select("id")); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	// invalid statements are not run and not reported
+	Delete("users").Exec(ctx, db)
+
+	want := []string{"before a", "before b", "after b b", "after a b", "before a", "before b", "after b b", "after a b"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("invalid calls: '%v'", calls)
+	}
+
+	if len(a.infos) != 2 {
+		t.Fatalf("invalid infos: %d", len(a.infos))
+	}
+	q := a.infos[0]
+	if q.SQL != "UPDATE users SET active=$1 WHERE id=$2" || !reflect.DeepEqual(q.Args, []any{false, 7}) {
+		t.Errorf("invalid query: '%s' %v", q.SQL, q.Args)
+	}
+	if q.RowsAffected != 2 || q.Err != nil || q.Start.IsZero() || q.Duration <= 0 {
+		t.Errorf("invalid info: %+v", q)
+	}
+	if q := a.infos[1]; q.SQL != "SELECT id FROM users" || q.RowsAffected != -1 {
+		t.Errorf("invalid info: %+v", q)
+	}
+}
+
+func TestSlogHook(t *testing.T) {
+	SetDialect(PostgreSQL)
+	defer SetHooks()
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	SetHooks(&SlogHook{Logger: logger, Level: slog.LevelDebug, Redactor: &Redactor{Columns: []string{"password"}}})
+
+	db, _ := openTestDB(t, nil)
+	Update("users").Set("password", "s3cret").Where("id", 7).Exec(ctx, db)
+
+	out := buf.String()
+	if strings.Contains(out, "s3cret") {
+		t.Errorf("password logged: %s", out)
+	}
+	for _, s := range []string{"level=DEBUG", `msg=query`, `sql="UPDATE users SET password=$1 WHERE id=$2"`, "args=\"[[REDACTED] 7]\"", "duration=", "rows=0"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("missing %s: %s", s, out)
+		}
+	}
+
+	buf.Reset()
+	_, done := TraceQuery(ctx, "SELECT 1", nil)
+	done(-1, errors.New("boom"))
+	if out := buf.String(); !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "error=boom") || strings.Contains(out, "rows=") {
+		t.Errorf("invalid log: %s", out)
+	}
+}
+
+type testSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttribute(key string, value any) { s.attrs[key] = value }
+func (s *testSpan) RecordError(err error)              { s.err = err }
+func (s *testSpan) End()                               { s.ended = true }
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	span := &testSpan{name: name, attrs: map[string]any{}}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestTraceHook(t *testing.T) {
+	SetDialect(PostgreSQL)
+	defer SetHooks()
+	ctx := context.Background()
+
+	tracer := &testTracer{}
+	SetHooks(&TraceHook{Tracer: tracer})
+
+	db, _ := openTestDB(t, nil)
+	Delete("sessions").Where("user_id", 7).Exec(ctx, db)
+	_, done := TraceQuery(ctx, "SELECT 1", nil)
+	done(-1, errors.New("boom"))
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("invalid spans: %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	want := map[string]any{"db.statement": "DELETE FROM sessions WHERE user_id=$1", "db.rows_affected": int64(0)}
+	if span.name != "sqls DELETE" || !reflect.DeepEqual(span.attrs, want) || !span.ended || span.err != nil {
+		t.Errorf("invalid span: %+v", span)
+	}
+	if span := tracer.spans[1]; span.name != "sqls SELECT" || span.err == nil || !span.ended {
+		t.Errorf("invalid span: %+v", span)
+	}
+}
@@ -0,0 +1,586 @@
+package sqls
+
+import "slices"
+
+// SelectQuery is an immutable SELECT statement. Every method returns a new query
+// and leaves the receiver unchanged, so a query can be stored in a package-level
+// variable and extended from many goroutines. Slices are shared between queries
+// and copied on write. The zero value is a query without a table.
+type SelectQuery struct {
+	stmt *SelectStmt
+}
+
+// UpdateQuery is an immutable UPDATE statement, see SelectQuery.
+type UpdateQuery struct {
+	stmt *UpdateStmt
+}
+
+// DeleteQuery is an immutable DELETE statement, see SelectQuery.
+type DeleteQuery struct {
+	stmt *DeleteStmt
+}
+
+// get returns the statement of the query, or an empty statement for the zero value.
+func (q SelectQuery) get() *SelectStmt {
+	if q.stmt == nil {
+		return &SelectStmt{}
+	}
+	return q.stmt
+}
+
+// get returns the statement of the query, or an empty statement for the zero value.
+func (q UpdateQuery) get() *UpdateStmt {
+	if q.stmt == nil {
+		return &UpdateStmt{}
+	}
+	return q.stmt
+}
+
+// get returns the statement of the query, or an empty statement for the zero value.
+func (q DeleteQuery) get() *DeleteStmt {
+	if q.stmt == nil {
+		return &DeleteStmt{}
+	}
+	return q.stmt
+}
+
+// renderDialect returns the dialect the query is rendered with, see Compile.
+func (q SelectQuery) renderDialect() *Dialect {
+	return q.get().renderDialect()
+}
+
+// renderDialect returns the dialect the query is rendered with, see Compile.
+func (q UpdateQuery) renderDialect() *Dialect {
+	return q.get().renderDialect()
+}
+
+// renderDialect returns the dialect the query is rendered with, see Compile.
+func (q DeleteQuery) renderDialect() *Dialect {
+	return q.get().renderDialect()
+}
+
+// Immutable returns an immutable copy of the SELECT statement.
+func (s *SelectStmt) Immutable() SelectQuery {
+	return SelectQuery{s.Clone()}
+}
+
+// Immutable returns an immutable copy of the UPDATE statement.
+func (s *UpdateStmt) Immutable() UpdateQuery {
+	return UpdateQuery{s.Clone()}
+}
+
+// Immutable returns an immutable copy of the DELETE statement.
+func (s *DeleteStmt) Immutable() DeleteQuery {
+	return DeleteQuery{s.Clone()}
+}
+
+// Stmt returns a mutable copy of the query.
+func (q SelectQuery) Stmt() *SelectStmt {
+	return q.get().Clone()
+}
+
+// Stmt returns a mutable copy of the query.
+func (q UpdateQuery) Stmt() *UpdateStmt {
+	return q.get().Clone()
+}
+
+// Stmt returns a mutable copy of the query.
+func (q DeleteQuery) Stmt() *DeleteStmt {
+	return q.get().Clone()
+}
+
+// ToSql generates the SQL query string and the corresponding arguments for the query.
+func (q SelectQuery) ToSql() (string, []any) {
+	return q.get().ToSql()
+}
+
+// Build generates the SQL query string and the corresponding arguments for the query, see SelectStmt.Build.
+func (q SelectQuery) Build() (string, []any, error) {
+	return q.get().Build()
+}
+
+// ToSql generates the SQL query string and the corresponding arguments for the query.
+func (q UpdateQuery) ToSql() (string, []any) {
+	return q.get().ToSql()
+}
+
+// Build generates the SQL query string and the corresponding arguments for the query, see UpdateStmt.Build.
+func (q UpdateQuery) Build() (string, []any, error) {
+	return q.get().Build()
+}
+
+// ToSql generates the SQL query string and the corresponding arguments for the query.
+func (q DeleteQuery) ToSql() (string, []any) {
+	return q.get().ToSql()
+}
+
+// Build generates the SQL query string and the corresponding arguments for the query, see DeleteStmt.Build.
+func (q DeleteQuery) Build() (string, []any, error) {
+	return q.get().Build()
+}
+
+// Count derives a query that counts the rows matched by the query, see SelectStmt.Count.
+func (q SelectQuery) Count() SelectQuery {
+	return SelectQuery{q.get().Count()}
+}
+
+// derive applies f to a copy of the statement whose slices are clipped, so
+// appending to them allocates instead of writing to the shared arrays.
+func (q SelectQuery) derive(f func(s *SelectStmt)) SelectQuery {
+	s := *q.get()
+	s.columns = slices.Clip(s.columns)
+	s.where = slices.Clip(s.where)
+	s.args = slices.Clip(s.args)
+	s.joins = slices.Clip(s.joins)
+	f(&s)
+	return SelectQuery{&s}
+}
+
+// derive applies f to a copy of the statement, see SelectQuery.derive. The joins
+// are copied since On changes the last join in place.
+func (q UpdateQuery) derive(f func(s *UpdateStmt)) UpdateQuery {
+	s := *q.get()
+	s.columns = slices.Clip(s.columns)
+	s.from = slices.Clip(s.from)
+	s.joins = cloneJoins(s.joins)
+	s.where = slices.Clip(s.where)
+	s.args = slices.Clip(s.args)
+	f(&s)
+	return UpdateQuery{&s}
+}
+
+// derive applies f to a copy of the statement, see UpdateQuery.derive.
+func (q DeleteQuery) derive(f func(s *DeleteStmt)) DeleteQuery {
+	s := *q.get()
+	s.using = slices.Clip(s.using)
+	s.joins = cloneJoins(s.joins)
+	s.where = slices.Clip(s.where)
+	s.args = slices.Clip(s.args)
+	f(&s)
+	return DeleteQuery{&s}
+}
+
+// Select returns a copy of the query with SelectStmt.Select applied.
+func (q SelectQuery) Select(columns ...string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Select(columns...) })
+}
+
+// Where returns a copy of the query with SelectStmt.Where applied.
+func (q SelectQuery) Where(column string, value any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Where(column, value) })
+}
+
+// WhereNotEquals returns a copy of the query with SelectStmt.WhereNotEquals applied.
+func (q SelectQuery) WhereNotEquals(column string, value any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNotEquals(column, value) })
+}
+
+// WhereNull returns a copy of the query with SelectStmt.WhereNull applied.
+func (q SelectQuery) WhereNull(column string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNull(column) })
+}
+
+// WhereNotNull returns a copy of the query with SelectStmt.WhereNotNull applied.
+func (q SelectQuery) WhereNotNull(column string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNotNull(column) })
+}
+
+// WhereExp returns a copy of the query with SelectStmt.WhereExp applied.
+func (q SelectQuery) WhereExp(column string, ex string, value any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereExp(column, ex, value) })
+}
+
+// WhereIn returns a copy of the query with SelectStmt.WhereIn applied.
+func (q SelectQuery) WhereIn(column string, values []any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereIn(column, values) })
+}
+
+// WhereNotIn returns a copy of the query with SelectStmt.WhereNotIn applied.
+func (q SelectQuery) WhereNotIn(column string, values []any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNotIn(column, values) })
+}
+
+// WhereAny returns a copy of the query with SelectStmt.WhereAny applied.
+func (q SelectQuery) WhereAny(column string, values any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereAny(column, values) })
+}
+
+// WhereBetween returns a copy of the query with SelectStmt.WhereBetween applied.
+func (q SelectQuery) WhereBetween(column string, from any, to any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereBetween(column, from, to) })
+}
+
+// WhereNotBetween returns a copy of the query with SelectStmt.WhereNotBetween applied.
+func (q SelectQuery) WhereNotBetween(column string, from any, to any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNotBetween(column, from, to) })
+}
+
+// WhereLike returns a copy of the query with SelectStmt.WhereLike applied.
+func (q SelectQuery) WhereLike(column string, value string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereLike(column, value) })
+}
+
+// WhereNotLike returns a copy of the query with SelectStmt.WhereNotLike applied.
+func (q SelectQuery) WhereNotLike(column string, value string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNotLike(column, value) })
+}
+
+// WhereILike returns a copy of the query with SelectStmt.WhereILike applied.
+func (q SelectQuery) WhereILike(column string, value string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereILike(column, value) })
+}
+
+// WhereLikePattern returns a copy of the query with SelectStmt.WhereLikePattern applied.
+func (q SelectQuery) WhereLikePattern(column string, pattern string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereLikePattern(column, pattern) })
+}
+
+// WhereNotLikePattern returns a copy of the query with SelectStmt.WhereNotLikePattern applied.
+func (q SelectQuery) WhereNotLikePattern(column string, pattern string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereNotLikePattern(column, pattern) })
+}
+
+// WhereILikePattern returns a copy of the query with SelectStmt.WhereILikePattern applied.
+func (q SelectQuery) WhereILikePattern(column string, pattern string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereILikePattern(column, pattern) })
+}
+
+// WhereRow returns a copy of the query with SelectStmt.WhereRow applied.
+func (q SelectQuery) WhereRow(columns []string, ex string, values []any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereRow(columns, ex, values) })
+}
+
+// WhereRaw returns a copy of the query with SelectStmt.WhereRaw applied.
+func (q SelectQuery) WhereRaw(raw string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.WhereRaw(raw) })
+}
+
+// OrderBy returns a copy of the query with SelectStmt.OrderBy applied.
+func (q SelectQuery) OrderBy(columns ...string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.OrderBy(columns...) })
+}
+
+// Limit returns a copy of the query with SelectStmt.Limit applied.
+func (q SelectQuery) Limit(limit int) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Limit(limit) })
+}
+
+// Offset returns a copy of the query with SelectStmt.Offset applied.
+func (q SelectQuery) Offset(offset int) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Offset(offset) })
+}
+
+// Join returns a copy of the query with SelectStmt.Join applied.
+func (q SelectQuery) Join(table string, on1 string, on2 string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Join(table, on1, on2) })
+}
+
+// GroupBy returns a copy of the query with SelectStmt.GroupBy applied.
+func (q SelectQuery) GroupBy(columns ...string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.GroupBy(columns...) })
+}
+
+// Having returns a copy of the query with SelectStmt.Having applied.
+func (q SelectQuery) Having(conditions ...string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Having(conditions...) })
+}
+
+// ClearSelect returns a copy of the query with SelectStmt.ClearSelect applied.
+func (q SelectQuery) ClearSelect() SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.ClearSelect() })
+}
+
+// ClearWhere returns a copy of the query with SelectStmt.ClearWhere applied.
+func (q SelectQuery) ClearWhere() SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.ClearWhere() })
+}
+
+// ClearJoin returns a copy of the query with SelectStmt.ClearJoin applied.
+func (q SelectQuery) ClearJoin() SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.ClearJoin() })
+}
+
+// ClearGroupBy returns a copy of the query with SelectStmt.ClearGroupBy applied.
+func (q SelectQuery) ClearGroupBy() SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.ClearGroupBy() })
+}
+
+// ClearHaving returns a copy of the query with SelectStmt.ClearHaving applied.
+func (q SelectQuery) ClearHaving() SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.ClearHaving() })
+}
+
+// ClearOrderBy returns a copy of the query with SelectStmt.ClearOrderBy applied.
+func (q SelectQuery) ClearOrderBy() SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.ClearOrderBy() })
+}
+
+// Keyset returns a copy of the query with SelectStmt.Keyset applied.
+func (q SelectQuery) Keyset(sort []Sort, after []any) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.Keyset(sort, after) })
+}
+
+// KeysetCursor returns a copy of the query with SelectStmt.KeysetCursor applied.
+func (q SelectQuery) KeysetCursor(codec *CursorCodec, sort []Sort, cursor string) SelectQuery {
+	return q.derive(func(s *SelectStmt) { s.KeysetCursor(codec, sort, cursor) })
+}
+
+// Set returns a copy of the query with UpdateStmt.Set applied.
+func (q UpdateQuery) Set(column string, value any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.Set(column, value) })
+}
+
+// SetValues returns a copy of the query with UpdateStmt.SetValues applied.
+func (q UpdateQuery) SetValues(values []KeyVal) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.SetValues(values) })
+}
+
+// Where returns a copy of the query with UpdateStmt.Where applied.
+func (q UpdateQuery) Where(column string, value any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.Where(column, value) })
+}
+
+// WhereNotEquals returns a copy of the query with UpdateStmt.WhereNotEquals applied.
+func (q UpdateQuery) WhereNotEquals(column string, value any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNotEquals(column, value) })
+}
+
+// WhereNull returns a copy of the query with UpdateStmt.WhereNull applied.
+func (q UpdateQuery) WhereNull(column string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNull(column) })
+}
+
+// WhereNotNull returns a copy of the query with UpdateStmt.WhereNotNull applied.
+func (q UpdateQuery) WhereNotNull(column string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNotNull(column) })
+}
+
+// WhereExp returns a copy of the query with UpdateStmt.WhereExp applied.
+func (q UpdateQuery) WhereExp(column string, eq string, value any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereExp(column, eq, value) })
+}
+
+// WhereIn returns a copy of the query with UpdateStmt.WhereIn applied.
+func (q UpdateQuery) WhereIn(column string, in []any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereIn(column, in) })
+}
+
+// WhereNotIn returns a copy of the query with UpdateStmt.WhereNotIn applied.
+func (q UpdateQuery) WhereNotIn(column string, values []any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNotIn(column, values) })
+}
+
+// WhereAny returns a copy of the query with UpdateStmt.WhereAny applied.
+func (q UpdateQuery) WhereAny(column string, values any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereAny(column, values) })
+}
+
+// WhereBetween returns a copy of the query with UpdateStmt.WhereBetween applied.
+func (q UpdateQuery) WhereBetween(column string, from any, to any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereBetween(column, from, to) })
+}
+
+// WhereNotBetween returns a copy of the query with UpdateStmt.WhereNotBetween applied.
+func (q UpdateQuery) WhereNotBetween(column string, from any, to any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNotBetween(column, from, to) })
+}
+
+// WhereLike returns a copy of the query with UpdateStmt.WhereLike applied.
+func (q UpdateQuery) WhereLike(column string, value string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereLike(column, value) })
+}
+
+// WhereNotLike returns a copy of the query with UpdateStmt.WhereNotLike applied.
+func (q UpdateQuery) WhereNotLike(column string, value string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNotLike(column, value) })
+}
+
+// WhereILike returns a copy of the query with UpdateStmt.WhereILike applied.
+func (q UpdateQuery) WhereILike(column string, value string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereILike(column, value) })
+}
+
+// WhereLikePattern returns a copy of the query with UpdateStmt.WhereLikePattern applied.
+func (q UpdateQuery) WhereLikePattern(column string, pattern string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereLikePattern(column, pattern) })
+}
+
+// WhereNotLikePattern returns a copy of the query with UpdateStmt.WhereNotLikePattern applied.
+func (q UpdateQuery) WhereNotLikePattern(column string, pattern string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereNotLikePattern(column, pattern) })
+}
+
+// WhereILikePattern returns a copy of the query with UpdateStmt.WhereILikePattern applied.
+func (q UpdateQuery) WhereILikePattern(column string, pattern string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereILikePattern(column, pattern) })
+}
+
+// WhereRow returns a copy of the query with UpdateStmt.WhereRow applied.
+func (q UpdateQuery) WhereRow(columns []string, ex string, values []any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereRow(columns, ex, values) })
+}
+
+// WhereRaw returns a copy of the query with UpdateStmt.WhereRaw applied.
+func (q UpdateQuery) WhereRaw(raw string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.WhereRaw(raw) })
+}
+
+// From returns a copy of the query with UpdateStmt.From applied.
+func (q UpdateQuery) From(tables ...string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.From(tables...) })
+}
+
+// Join returns a copy of the query with UpdateStmt.Join applied.
+func (q UpdateQuery) Join(table string, on1 string, on2 string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.Join(table, on1, on2) })
+}
+
+// On returns a copy of the query with UpdateStmt.On applied.
+func (q UpdateQuery) On(column string, value any) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.On(column, value) })
+}
+
+// OrderBy returns a copy of the query with UpdateStmt.OrderBy applied.
+func (q UpdateQuery) OrderBy(columns ...string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.OrderBy(columns...) })
+}
+
+// Limit returns a copy of the query with UpdateStmt.Limit applied.
+func (q UpdateQuery) Limit(limit int) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.Limit(limit) })
+}
+
+// Key returns a copy of the query with UpdateStmt.Key applied.
+func (q UpdateQuery) Key(column string) UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.Key(column) })
+}
+
+// AllRows returns a copy of the query with UpdateStmt.AllRows applied.
+func (q UpdateQuery) AllRows() UpdateQuery {
+	return q.derive(func(s *UpdateStmt) { s.AllRows() })
+}
+
+// Where returns a copy of the query with DeleteStmt.Where applied.
+func (q DeleteQuery) Where(column string, value any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.Where(column, value) })
+}
+
+// WhereNotEquals returns a copy of the query with DeleteStmt.WhereNotEquals applied.
+func (q DeleteQuery) WhereNotEquals(column string, value any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNotEquals(column, value) })
+}
+
+// WhereNull returns a copy of the query with DeleteStmt.WhereNull applied.
+func (q DeleteQuery) WhereNull(column string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNull(column) })
+}
+
+// WhereNotNull returns a copy of the query with DeleteStmt.WhereNotNull applied.
+func (q DeleteQuery) WhereNotNull(column string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNotNull(column) })
+}
+
+// WhereExp returns a copy of the query with DeleteStmt.WhereExp applied.
+func (q DeleteQuery) WhereExp(column string, ex string, value any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereExp(column, ex, value) })
+}
+
+// WhereIn returns a copy of the query with DeleteStmt.WhereIn applied.
+func (q DeleteQuery) WhereIn(column string, in []any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereIn(column, in) })
+}
+
+// WhereNotIn returns a copy of the query with DeleteStmt.WhereNotIn applied.
+func (q DeleteQuery) WhereNotIn(column string, values []any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNotIn(column, values) })
+}
+
+// WhereAny returns a copy of the query with DeleteStmt.WhereAny applied.
+func (q DeleteQuery) WhereAny(column string, values any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereAny(column, values) })
+}
+
+// WhereBetween returns a copy of the query with DeleteStmt.WhereBetween applied.
+func (q DeleteQuery) WhereBetween(column string, from any, to any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereBetween(column, from, to) })
+}
+
+// WhereNotBetween returns a copy of the query with DeleteStmt.WhereNotBetween applied.
+func (q DeleteQuery) WhereNotBetween(column string, from any, to any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNotBetween(column, from, to) })
+}
+
+// WhereLike returns a copy of the query with DeleteStmt.WhereLike applied.
+func (q DeleteQuery) WhereLike(column string, value string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereLike(column, value) })
+}
+
+// WhereNotLike returns a copy of the query with DeleteStmt.WhereNotLike applied.
+func (q DeleteQuery) WhereNotLike(column string, value string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNotLike(column, value) })
+}
+
+// WhereILike returns a copy of the query with DeleteStmt.WhereILike applied.
+func (q DeleteQuery) WhereILike(column string, value string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereILike(column, value) })
+}
+
+// WhereLikePattern returns a copy of the query with DeleteStmt.WhereLikePattern applied.
+func (q DeleteQuery) WhereLikePattern(column string, pattern string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereLikePattern(column, pattern) })
+}
+
+// WhereNotLikePattern returns a copy of the query with DeleteStmt.WhereNotLikePattern applied.
+func (q DeleteQuery) WhereNotLikePattern(column string, pattern string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereNotLikePattern(column, pattern) })
+}
+
+// WhereILikePattern returns a copy of the query with DeleteStmt.WhereILikePattern applied.
+func (q DeleteQuery) WhereILikePattern(column string, pattern string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereILikePattern(column, pattern) })
+}
+
+// WhereRow returns a copy of the query with DeleteStmt.WhereRow applied.
+func (q DeleteQuery) WhereRow(columns []string, ex string, values []any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereRow(columns, ex, values) })
+}
+
+// WhereRaw returns a copy of the query with DeleteStmt.WhereRaw applied.
+func (q DeleteQuery) WhereRaw(raw string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.WhereRaw(raw) })
+}
+
+// Using returns a copy of the query with DeleteStmt.Using applied.
+func (q DeleteQuery) Using(tables ...string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.Using(tables...) })
+}
+
+// Join returns a copy of the query with DeleteStmt.Join applied.
+func (q DeleteQuery) Join(table string, on1 string, on2 string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.Join(table, on1, on2) })
+}
+
+// On returns a copy of the query with DeleteStmt.On applied.
+func (q DeleteQuery) On(column string, value any) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.On(column, value) })
+}
+
+// OrderBy returns a copy of the query with DeleteStmt.OrderBy applied.
+func (q DeleteQuery) OrderBy(columns ...string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.OrderBy(columns...) })
+}
+
+// Limit returns a copy of the query with DeleteStmt.Limit applied.
+func (q DeleteQuery) Limit(limit int) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.Limit(limit) })
+}
+
+// Key returns a copy of the query with DeleteStmt.Key applied.
+func (q DeleteQuery) Key(column string) DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.Key(column) })
+}
+
+// AllRows returns a copy of the query with DeleteStmt.AllRows applied.
+func (q DeleteQuery) AllRows() DeleteQuery {
+	return q.derive(func(s *DeleteStmt) { s.AllRows() })
+}
@@ -0,0 +1,114 @@
+package sqls
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestImmutable(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	base := From("users").Select("id").Where("tenant", 1).Join("roles", "users.id", "roles.user_id").Immutable()
+
+	a := base.Where("active", true)
+	b := base.Where("role", "admin").Select("id", "name").Limit(5)
+
+	sql, args := base.ToSql()
+	if sql != `SELECT id FROM users JOIN roles ON users.id=roles.user_id WHERE tenant=$1` {
+		t.Error("Invalid sql: " + sql)
+	}
+	if !reflect.DeepEqual(args, []any{1}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+
+	sql, args = a.ToSql()
+	if sql != `SELECT id FROM users JOIN roles ON users.id=roles.user_id WHERE tenant=$1 AND active=$2` {
+		t.Error("Invalid sql: " + sql)
+	}
+	if !reflect.DeepEqual(args, []any{1, true}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+
+	sql, args = b.ToSql()
+	if sql != `SELECT id,name FROM users JOIN roles ON users.id=roles.user_id WHERE tenant=$1 AND role=$2 LIMIT 5` {
+		t.Error("Invalid sql: " + sql)
+	}
+	if !reflect.DeepEqual(args, []any{1, "admin"}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+}
+
+// TestImmutableConcurrent derives queries from shared bases in parallel, run it
+// with -race to check that they do not write to shared memory.
+func TestImmutableConcurrent(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	selectBase := From("users").Where("tenant", 1).Immutable()
+	updateBase := Update("orders o").Set("status", "shipped").Join("customers c", "o.customer_id", "c.id").Immutable()
+	deleteBase := Delete("sessions s").Join("users u", "s.user_id", "u.id").Immutable()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 300)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			sql, args := selectBase.Where("id", i).WhereIn("role", []any{"a", "b"}).Limit(i + 1).ToSql()
+			want := fmt.Sprintf("SELECT * FROM users WHERE tenant=$1 AND id=$2 AND role IN ($3,$4) LIMIT %d", i+1)
+			if sql != want || !reflect.DeepEqual(args, []any{1, i, "a", "b"}) {
+				errs <- fmt.Errorf("select %d: '%s' %v", i, sql, args)
+			}
+
+			sql, args = updateBase.On("c.region", i).Where("o.id", i).ToSql()
+			want = "UPDATE orders o SET status=$1 FROM customers c WHERE o.customer_id=c.id AND c.region=$2 AND o.id=$3"
+			if sql != want || !reflect.DeepEqual(args, []any{"shipped", i, i}) {
+				errs <- fmt.Errorf("update %d: '%s' %v", i, sql, args)
+			}
+
+			sql, args = deleteBase.On("u.banned", true).Where("s.id", i).ToSql()
+			want = "DELETE FROM sessions s USING users u WHERE s.user_id=u.id AND u.banned=$1 AND s.id=$2"
+			if sql != want || !reflect.DeepEqual(args, []any{true, i}) {
+				errs <- fmt.Errorf("delete %d: '%s' %v", i, sql, args)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestImmutableZero(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	var s SelectQuery
+	if _, _, err := s.Where("id", 1).Build(); !errors.Is(err, ErrNoTable) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if sql, args := s.ToSql(); sql != "SELECT * FROM " || args != nil {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if _, _, err := s.Count().Build(); !errors.Is(err, ErrNoTable) {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	var u UpdateQuery
+	if _, _, err := u.Set("active", true).Where("id", 1).Build(); !errors.Is(err, ErrNoTable) {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	var d DeleteQuery
+	if _, _, err := d.Build(); !errors.Is(err, ErrNoTable) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if d.Stmt() == nil {
+		t.Error("invalid stmt")
+	}
+}
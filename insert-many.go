@@ -1,14 +1,15 @@
 package sqls
 
-import "strings"
+import "slices"
 
 type InsertManyStmt struct {
 	table     string
 	columns   []string
 	args      []any
 	count     int
-	returning string
+	returning []string
 	conflict  string
+	widths    rowWidths
 }
 
 // InsertMany creates a new INSERT statement for multiple rows.
@@ -28,6 +29,7 @@ func (s *InsertManyStmt) Columns(columns ...string) *InsertManyStmt {
 func (s *InsertManyStmt) Values(values ...any) *InsertManyStmt {
 	s.args = append(s.args, values...)
 	s.count++
+	s.widths.add(s.count, len(values))
 	return s
 }
 
@@ -35,31 +37,154 @@ func (s *InsertManyStmt) Values(values ...any) *InsertManyStmt {
 func (s *InsertManyStmt) Clear() *InsertManyStmt {
 	s.args = []any{}
 	s.count = 0
+	s.widths = rowWidths{}
 	return s
 }
 
 // OnConflict specifies the conflict resolution strategy in the INSERT statement.
 func (s *InsertManyStmt) OnConflict(expression string) *InsertManyStmt {
-	s.conflict = " ON CONFLICT " + expression
+	s.conflict = expression
 	return s
 }
 
 // Returning specifies the columns to be returned in the INSERT statement.
 func (s *InsertManyStmt) Returning(columns ...string) *InsertManyStmt {
-	s.returning = " RETURNING " + strings.Join(columns, ",")
+	s.returning = columns
 	return s
 }
 
+// Clone returns a deep copy of the INSERT statement.
+func (s *InsertManyStmt) Clone() *InsertManyStmt {
+	c := *s
+	c.columns = slices.Clone(s.columns)
+	c.args = slices.Clone(s.args)
+	c.returning = slices.Clone(s.returning)
+	return &c
+}
+
 // ToSql generates the SQL and returns the parameters.
 func (s *InsertManyStmt) ToSql() (string, []any) {
-	var values []string
+	return render(curDialect.Load(), s.appendSql, s.args)
+}
+
+// AppendSql appends the SQL to buf and returns the extended buffer and the parameters.
+func (s *InsertManyStmt) AppendSql(buf []byte) ([]byte, []any) {
+	d := curDialect.Load()
+	return d.bindOrder(s.appendSql(buf, d), len(buf), s.args)
+}
+
+func (s *InsertManyStmt) appendSql(b []byte, d *Dialect) []byte {
 	length := len(s.columns)
 	end := s.count * length
 
+	b = append(b, "INSERT INTO "...)
+	b = append(b, s.table...)
+	b = append(b, '(')
+	b = appendList(b, s.columns, ",")
+	b = append(b, ") VALUES "...)
+
 	for i := 1; i <= end; i += length {
-		values = append(values, params(i, length))
+		if i > 1 {
+			b = append(b, ',')
+		}
+		b = append(b, '(')
+		b = d.appendParams(b, i, length)
+		b = append(b, ')')
+	}
+	return appendConflictReturning(b, s.conflict, s.returning)
+}
+
+// Chunks splits the INSERT statement into statements that stay within the
+// parameter limit of the current dialect, and within 1000 rows on SQL Server.
+// An invalid statement is not split.
+func (s *InsertManyStmt) Chunks() []*InsertManyStmt {
+	length := len(s.columns)
+	size := curDialect.Load().chunkSize(length)
+	if s.count <= size || s.validate() != nil {
+		return []*InsertManyStmt{s}
+	}
+
+	chunks := make([]*InsertManyStmt, 0, (s.count+size-1)/size)
+	for i := 0; i < s.count; i += size {
+		count := min(size, s.count-i)
+		chunk := *s
+		chunk.args = s.args[i*length : (i+count)*length : (i+count)*length]
+		chunk.count = count
+		chunks = append(chunks, &chunk)
+	}
+	return chunks
+}
+
+// CopyData returns the table, the columns and the rows of the statement for a
+// bulk load such as PostgreSQL COPY. It returns the errors of Build, and
+// ErrNotSupported for a statement with ON CONFLICT or RETURNING.
+func (s *InsertManyStmt) CopyData() (string, []string, [][]any, error) {
+	if err := s.validate(); err != nil {
+		return "", nil, nil, err
+	}
+	if s.conflict != "" || s.returning != nil {
+		return "", nil, nil, &BuildError{Statement: "INSERT", Err: ErrNotSupported, Detail: "ON CONFLICT or RETURNING in a bulk load"}
+	}
+
+	length := len(s.columns)
+	rows := make([][]any, s.count)
+	for i := range rows {
+		rows[i] = s.args[i*length : (i+1)*length : (i+1)*length]
 	}
+	return s.table, s.columns, rows, nil
+}
+
+// Build generates the SQL and returns the parameters.
+// It returns a *BuildError if the statement is invalid.
+func (s *InsertManyStmt) Build() (string, []any, error) {
+	if err := s.validate(); err != nil {
+		return "", nil, err
+	}
+
+	query, args := s.ToSql()
+	return query, args, nil
+}
 
-	query := "INSERT INTO " + s.table + "(" + strings.Join(s.columns, ",") + ") VALUES (" + strings.Join(values, "),(") + ")" + s.conflict + s.returning
-	return query, s.args
+func (s *InsertManyStmt) validate() error {
+	if s.table == "" {
+		return &BuildError{Statement: "INSERT", Err: ErrNoTable}
+	}
+	if s.columns == nil {
+		return &BuildError{Statement: "INSERT", Err: ErrNoColumns}
+	}
+	if s.count == 0 {
+		return &BuildError{Statement: "INSERT", Err: ErrNoRows}
+	}
+	if err := s.widths.check(len(s.columns)); err != nil {
+		return err.in("INSERT")
+	}
+	return nil
+}
+
+// rowWidths tracks the number of values of the rows added to a statement, so
+// they can be checked against its columns when it is built, in whatever order
+// Columns and Values are called.
+type rowWidths struct {
+	first  int // values of the first row
+	row    int // the first row with another number of values, 0 if none
+	values int // values of that row
+}
+
+func (w *rowWidths) add(row int, values int) {
+	if row == 1 {
+		w.first = values
+	} else if w.row == 0 && values != w.first {
+		w.row, w.values = row, values
+	}
+}
+
+// check returns a *BuildError for the first row without one value per column.
+func (w *rowWidths) check(columns int) *BuildError {
+	if w.first != columns {
+		return valueCountError(1, w.first, columns)
+	}
+	if w.row != 0 {
+		return valueCountError(w.row, w.values, columns)
+	}
+	return nil
 }
@@ -1,7 +1,9 @@
 package sqls
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -56,3 +58,168 @@ func TestInsertMany(t *testing.T) {
 		}
 	})
 }
+
+func TestInsertManyLarge(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	s := InsertMany("users").Columns("name", "age")
+	for i := range 2000 {
+		s.Values("John", i)
+	}
+	sql, args := s.ToSql()
+
+	if !strings.HasSuffix(sql, ",($3997,$3998),($3999,$4000)") {
+		t.Errorf("invalid sql: '%s'", sql[len(sql)-40:])
+	}
+	if !strings.Contains(sql, ",($997,$998),($999,$1000),($1001,$1002),") {
+		t.Error("invalid sql at the end of the placeholder cache")
+	}
+	if len(args) != 4000 {
+		t.Errorf("invalid args: %d", len(args))
+	}
+}
+
+func TestInsertManyBuild(t *testing.T) {
+	SetDialect(DefaultDialect)
+
+	t.Run("Insert many wrong value count", func(t *testing.T) {
+		_, _, err := InsertMany("users").Columns("name", "age").
+			Values("John", 30).
+			Values("Jane").
+			Build()
+
+		if !errors.Is(err, ErrValueCount) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if err.Error() != "sqls: number of values does not match the columns in INSERT: row 2 has 1 values for 2 columns" {
+			t.Errorf("invalid message: %v", err)
+		}
+	})
+
+	t.Run("Insert many columns after values", func(t *testing.T) {
+		sql, args, err := InsertMany("users").
+			Values(1, "s").
+			Columns("id", "password").
+			Build()
+
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != "INSERT INTO users(id,password) VALUES (@1,@2)" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{1, "s"}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("Insert many wrong first row", func(t *testing.T) {
+		_, _, err := InsertMany("users").
+			Values("John").
+			Values("Jane").
+			Columns("name", "age").
+			Build()
+
+		if err == nil || err.Error() != "sqls: number of values does not match the columns in INSERT: row 1 has 1 values for 2 columns" {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("Insert many no rows", func(t *testing.T) {
+		_, _, err := InsertMany("users").Columns("name", "age").Build()
+
+		if !errors.Is(err, ErrNoRows) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("Insert many valid", func(t *testing.T) {
+		sql, args, err := InsertMany("users").Columns("name", "age").
+			Values("John", 30).
+			Build()
+
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != "INSERT INTO users(name,age) VALUES (@1,@2)" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{"John", 30}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+}
+
+func TestInsertManyClone(t *testing.T) {
+	SetDialect(DefaultDialect)
+
+	base := InsertMany("users").Columns("name", "age").Values("John", 30)
+	a := base.Clone().Values("Jane", 25)
+
+	sql, args := base.ToSql()
+	if sql != "INSERT INTO users(name,age) VALUES (@1,@2)" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{"John", 30}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+
+	sql, args = a.ToSql()
+	if sql != "INSERT INTO users(name,age) VALUES (@1,@2),(@3,@4)" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{"John", 30, "Jane", 25}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+}
+
+func TestInsertManyChunks(t *testing.T) {
+	SetDialect(SQLServer)
+	defer SetDialect(DefaultDialect)
+
+	// SQL Server takes 2100 parameters, 700 rows of 3 columns
+	s := InsertMany("users").Columns("name", "age", "role")
+	for i := range 1500 {
+		s.Values("John", i, "admin")
+	}
+
+	chunks := s.Chunks()
+	if len(chunks) != 3 {
+		t.Fatalf("invalid chunks: %d", len(chunks))
+	}
+	_, args := chunks[2].ToSql()
+	if len(args) != 300 || args[1] != 1400 {
+		t.Errorf("invalid args: %d", len(args))
+	}
+
+	// a VALUES list takes 1000 rows on SQL Server
+	names := InsertMany("tags").Columns("name")
+	for i := range 2500 {
+		names.Values(i)
+	}
+	chunks = names.Chunks()
+	if len(chunks) != 3 {
+		t.Fatalf("invalid chunks: %d", len(chunks))
+	}
+	if _, args := chunks[1].ToSql(); len(args) != 1000 || args[0] != 1000 {
+		t.Errorf("invalid args: %d", len(args))
+	}
+	if _, args := chunks[2].ToSql(); len(args) != 500 {
+		t.Errorf("invalid args: %d", len(args))
+	}
+
+	table, columns, rows, err := s.CopyData()
+	if err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if table != "users" || !reflect.DeepEqual(columns, []string{"name", "age", "role"}) {
+		t.Errorf("invalid table: '%s' %v", table, columns)
+	}
+	if len(rows) != 1500 || !reflect.DeepEqual(rows[1], []any{"John", 1, "admin"}) {
+		t.Errorf("invalid rows: %d", len(rows))
+	}
+
+	if _, _, _, err := s.Returning("id").CopyData(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("invalid error: %v", err)
+	}
+}
@@ -1,15 +1,13 @@
 package sqls
 
-import (
-	"strings"
-)
+import "slices"
 
 // InsertStmt represents an SQL INSERT statement.
 type InsertStmt struct {
 	table     string
 	columns   []string
 	args      []any
-	returning string
+	returning []string
 	conflict  string
 }
 
@@ -38,18 +36,58 @@ func (s *InsertStmt) SetValues(values []KeyVal) *InsertStmt {
 
 // Returning specifies the columns to be returned after the INSERT statement is executed.
 func (s *InsertStmt) Returning(columns ...string) *InsertStmt {
-	s.returning = " RETURNING " + strings.Join(columns, ",")
+	s.returning = columns
 	return s
 }
 
 // OnConflict specifies the conflict resolution strategy for the INSERT statement.
 func (s *InsertStmt) OnConflict(expression string) *InsertStmt {
-	s.conflict = " ON CONFLICT " + expression
+	s.conflict = expression
 	return s
 }
 
+// Clone returns a deep copy of the INSERT statement.
+func (s *InsertStmt) Clone() *InsertStmt {
+	c := *s
+	c.columns = slices.Clone(s.columns)
+	c.args = slices.Clone(s.args)
+	c.returning = slices.Clone(s.returning)
+	return &c
+}
+
 // ToSql generates the SQL query string and the corresponding arguments for the INSERT statement.
 func (s *InsertStmt) ToSql() (string, []any) {
-	sql := "INSERT INTO " + s.table + " (" + strings.Join(s.columns, ",") + ") VALUES (" + params(1, len(s.columns)) + ")" + s.conflict + s.returning
-	return sql, s.args
+	return render(curDialect.Load(), s.appendSql, s.args)
+}
+
+// AppendSql appends the SQL query of the INSERT statement to buf and returns the
+// extended buffer and the corresponding arguments.
+func (s *InsertStmt) AppendSql(buf []byte) ([]byte, []any) {
+	d := curDialect.Load()
+	return d.bindOrder(s.appendSql(buf, d), len(buf), s.args)
+}
+
+func (s *InsertStmt) appendSql(b []byte, d *Dialect) []byte {
+	b = append(b, "INSERT INTO "...)
+	b = append(b, s.table...)
+	b = append(b, " ("...)
+	b = appendList(b, s.columns, ",")
+	b = append(b, ") VALUES ("...)
+	b = d.appendParams(b, 1, len(s.columns))
+	b = append(b, ')')
+	return appendConflictReturning(b, s.conflict, s.returning)
+}
+
+// Build generates the SQL query string and the corresponding arguments for the INSERT statement.
+// It returns a *BuildError if the statement is invalid.
+func (s *InsertStmt) Build() (string, []any, error) {
+	if s.table == "" {
+		return "", nil, &BuildError{Statement: "INSERT", Err: ErrNoTable}
+	}
+	if s.columns == nil {
+		return "", nil, &BuildError{Statement: "INSERT", Err: ErrNoColumns}
+	}
+
+	query, args := s.ToSql()
+	return query, args, nil
 }
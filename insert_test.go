@@ -1,6 +1,7 @@
 package sqls
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -57,3 +58,25 @@ func TestInsert(t *testing.T) {
 	})
 
 }
+
+func TestInsertBuild(t *testing.T) {
+	SetDialect(DefaultDialect)
+
+	if _, _, err := Insert("users").Build(); !errors.Is(err, ErrNoColumns) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, _, err := Insert("").Set("email", "fake@email.com").Build(); !errors.Is(err, ErrNoTable) {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	sql, args, err := Insert("users").Set("email", "fake@email.com").Build()
+	if err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if sql != "INSERT INTO users (email) VALUES (@1)" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{"fake@email.com"}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+}
@@ -0,0 +1,185 @@
+package sqls
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Interpolate returns sql with its placeholders replaced by the arguments,
+// rendered as literals of the current dialect. Strings, []byte, times, bools,
+// numbers, NULLs and driver.Valuer values are rendered as literals, other
+// values as strings.
+//
+// The result is meant for logging and debugging only. It is not safe to
+// execute: run sql with its arguments instead.
+func Interpolate(sql string, args []any) string {
+	return curDialect.Load().interpolate(sql, args)
+}
+
+// DebugString returns the SQL of the statement with its arguments
+// interpolated as literals of the dialect of the statement, see Interpolate.
+// The statement is not validated, so invalid statements can be inspected too.
+// Never execute the result.
+func DebugString(stmt Statement) string {
+	sql, args := stmt.ToSql()
+	return dialectOf(stmt).interpolate(sql, args)
+}
+
+// interpolate replaces the placeholders of sql by the arguments rendered as
+// literals of d.
+func (d *Dialect) interpolate(sql string, args []any) string {
+	parts, refs := d.splitParams(sql, len(args))
+
+	b := make([]byte, 0, len(sql)+len(args)*8)
+	for i, ref := range refs {
+		b = append(b, parts[i]...)
+		b = d.appendLiteral(b, args[ref])
+	}
+	b = append(b, parts[len(parts)-1]...)
+	return string(b)
+}
+
+// appendLiteral appends the SQL literal of value.
+func (d *Dialect) appendLiteral(b []byte, value any) []byte {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+			return append(b, "NULL"...)
+		}
+		v, err := valuer.Value()
+		if err != nil {
+			return d.appendString(b, "!ERROR: "+err.Error())
+		}
+		value = v
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return append(b, "NULL"...)
+	case string:
+		return d.appendString(b, v)
+	case []byte:
+		if v == nil {
+			return append(b, "NULL"...)
+		}
+		return d.appendBytes(b, v)
+	case time.Time:
+		b = append(b, '\'')
+		b = v.AppendFormat(b, "2006-01-02 15:04:05.999999999Z07:00")
+		return append(b, '\'')
+	case bool:
+		return d.appendBool(b, v)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case float64:
+		return appendFloat(b, v, 64)
+	}
+
+	// named types, other sizes and pointers
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(b, "NULL"...)
+		}
+		return d.appendLiteral(b, v.Elem().Interface())
+	case reflect.String:
+		return d.appendString(b, v.String())
+	case reflect.Bool:
+		return d.appendBool(b, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(b, v.Uint(), 10)
+	case reflect.Float32:
+		return appendFloat(b, v.Float(), 32)
+	case reflect.Float64:
+		return appendFloat(b, v.Float(), 64)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return d.appendLiteral(b, v.Bytes())
+		}
+		// slices are bound as arrays on PostgreSQL, see WhereAny
+		if d.kind != kindPostgreSQL {
+			break
+		}
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, "NULL"...)
+		}
+		b = append(b, "ARRAY["...)
+		for i := range v.Len() {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = d.appendLiteral(b, v.Index(i).Interface())
+		}
+		return append(b, ']')
+	}
+	return d.appendString(b, fmt.Sprint(value))
+}
+
+// appendString appends s as a quoted string literal.
+func (d *Dialect) appendString(b []byte, s string) []byte {
+	b = append(b, '\'')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			b = append(b, '\'', '\'')
+		case c == '\\' && d.kind == kindMySQL:
+			// backslash is an escape character in MySQL string literals
+			b = append(b, '\\', '\\')
+		default:
+			b = append(b, c)
+		}
+	}
+	return append(b, '\'')
+}
+
+// appendBytes appends p as a hexadecimal binary literal.
+func (d *Dialect) appendBytes(b []byte, p []byte) []byte {
+	switch d.kind {
+	case kindPostgreSQL:
+		b = append(b, `'\x`...)
+		b = hex.AppendEncode(b, p)
+		return append(b, '\'')
+	case kindSQLServer:
+		b = append(b, "0x"...)
+		return hex.AppendEncode(b, p)
+	}
+	b = append(b, "X'"...)
+	b = hex.AppendEncode(b, p)
+	return append(b, '\'')
+}
+
+// appendBool appends TRUE or FALSE, or 1 or 0 on dialects without booleans.
+func (d *Dialect) appendBool(b []byte, v bool) []byte {
+	if d.kind == kindSQLServer || d.kind == kindSQLite {
+		if v {
+			return append(b, '1')
+		}
+		return append(b, '0')
+	}
+	if v {
+		return append(b, "TRUE"...)
+	}
+	return append(b, "FALSE"...)
+}
+
+// appendFloat appends f, quoting NaN and infinities which have no numeric literal.
+func appendFloat(b []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(b, "'NaN'"...)
+	case math.IsInf(f, 1):
+		return append(b, "'Infinity'"...)
+	case math.IsInf(f, -1):
+		return append(b, "'-Infinity'"...)
+	}
+	return strconv.AppendFloat(b, f, 'g', -1, bitSize)
+}
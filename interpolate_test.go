@@ -0,0 +1,93 @@
+package sqls
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type status string
+
+type badValuer struct{}
+
+func (badValuer) Value() (driver.Value, error) {
+	return nil, errors.New("bad value")
+}
+
+func TestInterpolate(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC)
+	name := "O'Brien"
+	var none *string
+
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		sql     string
+		args    []any
+		want    string
+	}{
+		{"postgres", PostgreSQL, `SELECT * FROM users WHERE email=$1 AND active=$2 AND id>$3`,
+			[]any{"a@b.com", true, 7}, `SELECT * FROM users WHERE email='a@b.com' AND active=TRUE AND id>7`},
+		{"reordered", PostgreSQL, `SELECT $2,$1,$2,$3`,
+			[]any{1, 2}, `SELECT 2,1,2,$3`},
+		{"quoted", PostgreSQL, `SELECT * FROM t WHERE a=$1 AND b='$1' AND c=$2`,
+			[]any{"$2", name}, `SELECT * FROM t WHERE a='$2' AND b='$1' AND c='O''Brien'`},
+		{"nulls", PostgreSQL, `INSERT INTO t(a,b,c,d) VALUES ($1,$2,$3,$4)`,
+			[]any{nil, none, sql.NullString{}, []byte(nil)}, `INSERT INTO t(a,b,c,d) VALUES (NULL,NULL,NULL,NULL)`},
+		{"valuers", PostgreSQL, `SELECT $1,$2,$3`,
+			[]any{sql.NullInt64{Int64: 5, Valid: true}, &sql.NullString{String: "x", Valid: true}, badValuer{}}, `SELECT 5,'x','!ERROR: bad value'`},
+		{"pointers and named types", PostgreSQL, `SELECT $1,$2,$3,$4`,
+			[]any{&name, status("on"), int8(-3), uint64(math.MaxUint64)}, `SELECT 'O''Brien','on',-3,18446744073709551615`},
+		{"floats", PostgreSQL, `SELECT $1,$2,$3,$4`,
+			[]any{1.5, float32(0.1), math.NaN(), math.Inf(-1)}, `SELECT 1.5,0.1,'NaN','-Infinity'`},
+		{"time", PostgreSQL, `SELECT $1,$2`,
+			[]any{at, at.In(time.FixedZone("", 2*3600))}, `SELECT '2024-03-01 12:30:00.0000005Z','2024-03-01 14:30:00.0000005+02:00'`},
+		{"postgres bytes and arrays", PostgreSQL, `SELECT $1,$2,$3`,
+			[]any{[]byte{0xde, 0xad}, []int{1, 2}, []string{"a", "b'"}}, `SELECT '\xdead',ARRAY[1,2],ARRAY['a','b''']`},
+		{"mysql", MySQL, `SELECT ?,?,?`,
+			[]any{`a\'b`, false, []byte{0x01}}, `SELECT 'a\\''b',FALSE,X'01'`},
+		{"sqlserver", SQLServer, `SELECT @1,@2,@3`,
+			[]any{`a\b`, true, []byte{0x01, 0xff}}, `SELECT 'a\b',1,0x01ff`},
+		{"sqlite", SQLite, `SELECT @1,@2,@3`,
+			[]any{false, []byte{}, []int{1}}, `SELECT 0,X'','[1]'`},
+		{"default", DefaultDialect, `SELECT @1,@2,@10`,
+			[]any{true, "x"}, `SELECT TRUE,'x',@10`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+			if got := Interpolate(tt.sql, tt.args); got != tt.want {
+				t.Errorf("invalid sql: '%s'", got)
+			}
+		})
+	}
+}
+
+func TestDebugString(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	stmt := From("users").Where("email", "a@b.com").Where("active", true).WhereIn("id", []any{1, 2})
+	want := `SELECT * FROM users WHERE email='a@b.com' AND active=TRUE AND id IN (1,2)`
+	if got := DebugString(stmt); got != want {
+		t.Errorf("invalid sql: '%s'", got)
+	}
+
+	// invalid statements are rendered too
+	want = `DELETE FROM users`
+	if got := DebugString(Delete("users")); got != want {
+		t.Errorf("invalid sql: '%s'", got)
+	}
+
+	// the literals are those of the dialect of the statement
+	stmt = From("files").Where("data", []byte{1}).Where("active", true)
+	SetDialect(SQLServer)
+	defer SetDialect(DefaultDialect)
+	want = `SELECT * FROM files WHERE data='\x01' AND active=TRUE`
+	if got := DebugString(stmt); got != want {
+		t.Errorf("invalid sql: '%s'", got)
+	}
+}
@@ -0,0 +1,162 @@
+package sqls
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Sort is a column and its direction in a keyset ordering.
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+// Asc sorts by the column in ascending order.
+func Asc(column string) Sort {
+	return Sort{Column: column}
+}
+
+// Desc sorts by the column in descending order.
+func Desc(column string) Sort {
+	return Sort{Column: column, Desc: true}
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return s.Column + " DESC"
+	}
+	return s.Column
+}
+
+// Keyset orders the SELECT statement by the sort columns and, if after is not
+// nil, adds a WHERE clause for the rows that follow the row with the sort values
+// in after. Use it with Limit instead of Offset to page through large tables.
+func (s *SelectStmt) Keyset(sort []Sort, after []any) *SelectStmt {
+	order := make([]string, len(sort))
+	for i, o := range sort {
+		order[i] = o.String()
+	}
+	s.OrderBy(order...)
+
+	if after != nil {
+		s.whereKeyset(sort, after)
+	}
+	return s
+}
+
+// supportsRowValues reports whether the dialect can compare row values such as (a,b)>(1,2).
+func (d *Dialect) supportsRowValues() bool {
+	switch d.kind {
+	case kindPostgreSQL, kindMySQL, kindSQLite:
+		return true
+	}
+	return false
+}
+
+// whereRow adds a (columns) operator (values) clause. Dialects without row values
+// get the equivalent expanded form.
+func (s *whereClause) whereRow(columns []string, ex string, values []any) {
+	op, err := ParseOp(ex)
+	if err == nil && !op.comparison() {
+		err = ErrInvalidOp
+	}
+	if err != nil || len(columns) != len(values) || len(columns) == 0 {
+		if s.invalid == nil {
+			if err != nil {
+				s.invalid = &BuildError{Err: ErrInvalidOp, Detail: strconv.Quote(ex)}
+			} else {
+				s.invalid = valueCountError(1, len(values), len(columns))
+			}
+		}
+		s.where = append(s.where, `1=0`)
+		return
+	}
+
+	p := s.bind(values)
+	if s.d().supportsRowValues() {
+		s.where = append(s.where, `(`+strings.Join(columns, `,`)+`)`+string(op)+`(`+strings.Join(p, `,`)+`)`)
+		return
+	}
+
+	switch op {
+	case Eq:
+		s.where = append(s.where, strings.Join(pairs(columns, "=", p), ` AND `))
+	case NotEq:
+		s.where = append(s.where, `(`+strings.Join(pairs(columns, "<>", p), ` OR `)+`)`)
+	default:
+		strict := strings.TrimSuffix(string(op), "=")
+		ops := make([]string, len(columns))
+		for i := range ops {
+			ops[i] = strict
+		}
+		ops[len(ops)-1] = string(op)
+		s.where = append(s.where, lexicographic(columns, ops, p))
+	}
+}
+
+// whereKeyset adds the clause selecting the rows after the given sort values.
+func (s *whereClause) whereKeyset(sort []Sort, values []any) {
+	if len(sort) != len(values) || len(sort) == 0 {
+		if s.invalid == nil {
+			s.invalid = valueCountError(1, len(values), len(sort))
+		}
+		s.where = append(s.where, `1=0`)
+		return
+	}
+
+	columns := make([]string, len(sort))
+	ops := make([]string, len(sort))
+	mixed := false
+	for i, o := range sort {
+		columns[i] = o.Column
+		ops[i] = ">"
+		if o.Desc {
+			ops[i] = "<"
+		}
+		mixed = mixed || ops[i] != ops[0]
+	}
+
+	if !mixed {
+		s.whereRow(columns, ops[0], values)
+		return
+	}
+	s.where = append(s.where, lexicographic(columns, ops, s.bind(values)))
+}
+
+// bind adds the values to the arguments and returns their placeholders.
+func (s *whereClause) bind(values []any) []string {
+	p := make([]string, len(values))
+	for i, v := range values {
+		s.args = append(s.args, v)
+		p[i] = s.d().params(len(s.args), 1)
+	}
+	return p
+}
+
+// lexicographic renders a lexicographic comparison of the columns as
+// (a>$1 OR (a=$1 AND b<$2) OR ...), using ops[i] for the i-th column.
+func lexicographic(columns []string, ops []string, p []string) string {
+	if len(columns) == 1 {
+		return columns[0] + ops[0] + p[0]
+	}
+
+	or := make([]string, len(columns))
+	for i := range columns {
+		and := pairs(columns[:i], "=", p[:i])
+		and = append(and, columns[i]+ops[i]+p[i])
+		if i == 0 {
+			or[i] = and[0]
+		} else {
+			or[i] = `(` + strings.Join(and, ` AND `) + `)`
+		}
+	}
+	return `(` + strings.Join(or, ` OR `) + `)`
+}
+
+func pairs(columns []string, op string, p []string) []string {
+	list := make([]string, len(columns))
+	for i, c := range columns {
+		list[i] = c + op + p[i]
+	}
+	return list
+}
@@ -0,0 +1,89 @@
+package sqls
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWhereRow(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		op      string
+		want    string
+	}{
+		{"postgres", PostgreSQL, ">", `SELECT * FROM events WHERE (created_at,id)>($1,$2)`},
+		{"sqlite", SQLite, "<=", `SELECT * FROM events WHERE (created_at,id)<=(@1,@2)`},
+		{"sql server >", SQLServer, ">", `SELECT * FROM events WHERE (created_at>@1 OR (created_at=@1 AND id>@2))`},
+		{"sql server <=", SQLServer, "<=", `SELECT * FROM events WHERE (created_at<@1 OR (created_at=@1 AND id<=@2))`},
+		{"sql server =", SQLServer, "=", `SELECT * FROM events WHERE created_at=@1 AND id=@2`},
+		{"sql server <>", SQLServer, "<>", `SELECT * FROM events WHERE (created_at<>@1 OR id<>@2)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args := From("events").
+				WhereRow([]string{"created_at", "id"}, tt.op, []any{"2024-01-01", 10}).
+				ToSql()
+
+			if sql != tt.want {
+				t.Error("Invalid sql: " + sql)
+			}
+			if !reflect.DeepEqual(args, []any{"2024-01-01", 10}) {
+				t.Errorf("Invalid args: %v", args)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		if _, _, err := From("events").WhereRow([]string{"a", "b"}, "LIKE", []any{1, 2}).Build(); !errors.Is(err, ErrInvalidOp) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if _, _, err := From("events").WhereRow([]string{"a", "b"}, ">", []any{1}).Build(); !errors.Is(err, ErrValueCount) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
+
+func TestKeyset(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		sort    []Sort
+		after   []any
+		want    string
+	}{
+		{"first page", PostgreSQL, []Sort{Desc("created_at"), Desc("id")}, nil,
+			`SELECT * FROM events WHERE tenant=$1 ORDER BY created_at DESC,id DESC LIMIT 20`},
+		{"row values", PostgreSQL, []Sort{Desc("created_at"), Desc("id")}, []any{"2024-01-01", 10},
+			`SELECT * FROM events WHERE tenant=$1 AND (created_at,id)<($2,$3) ORDER BY created_at DESC,id DESC LIMIT 20`},
+		{"expanded", SQLServer, []Sort{Asc("created_at"), Asc("id")}, []any{"2024-01-01", 10},
+			`SELECT * FROM events WHERE tenant=@1 AND (created_at>@2 OR (created_at=@2 AND id>@3)) ORDER BY created_at,id LIMIT 20`},
+		{"mixed", PostgreSQL, []Sort{Desc("score"), Asc("name"), Asc("id")}, []any{90, "John", 10},
+			`SELECT * FROM events WHERE tenant=$1 AND (score<$2 OR (score=$2 AND name>$3) OR (score=$2 AND name=$3 AND id>$4)) ORDER BY score DESC,name,id LIMIT 20`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args := From("events").
+				Where("tenant", 1).
+				Keyset(tt.sort, tt.after).
+				Limit(20).
+				ToSql()
+
+			if sql != tt.want {
+				t.Error("Invalid sql: " + sql)
+			}
+			if !reflect.DeepEqual(args, append([]any{1}, tt.after...)) {
+				t.Errorf("Invalid args: %v", args)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package sqls
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is a comparison operator for WhereExp.
+type Op string
+
+const (
+	Eq              Op = "="
+	NotEq           Op = "<>"
+	Lt              Op = "<"
+	LtEq            Op = "<="
+	Gt              Op = ">"
+	GtEq            Op = ">="
+	Like            Op = "LIKE"
+	NotLike         Op = "NOT LIKE"
+	ILike           Op = "ILIKE"
+	NotILike        Op = "NOT ILIKE"
+	DistinctFrom    Op = "IS DISTINCT FROM"
+	NotDistinctFrom Op = "IS NOT DISTINCT FROM"
+)
+
+var ops = map[string]Op{
+	"=":                    Eq,
+	"<>":                   NotEq,
+	"!=":                   NotEq,
+	"<":                    Lt,
+	"<=":                   LtEq,
+	">":                    Gt,
+	">=":                   GtEq,
+	"LIKE":                 Like,
+	"NOT LIKE":             NotLike,
+	"ILIKE":                ILike,
+	"NOT ILIKE":            NotILike,
+	"IS DISTINCT FROM":     DistinctFrom,
+	"IS NOT DISTINCT FROM": NotDistinctFrom,
+}
+
+// ParseOp parses a comparison operator such as ">=" or "not like". It ignores
+// case and extra whitespace and returns an error wrapping ErrInvalidOp for
+// anything that is not a known operator.
+func ParseOp(s string) (Op, error) {
+	op, ok := ops[strings.ToUpper(strings.Join(strings.Fields(s), " "))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidOp, s)
+	}
+	return op, nil
+}
+
+// comparison reports whether the operator compares values by order or equality.
+func (op Op) comparison() bool {
+	switch op {
+	case Eq, NotEq, Lt, LtEq, Gt, GtEq:
+		return true
+	}
+	return false
+}
+
+// sql returns the operator as it is written between a column and a value in the dialect.
+func (op Op) sql(d *Dialect) string {
+	if op.comparison() {
+		return string(op)
+	}
+
+	switch d.kind {
+	case kindMySQL:
+		if op == NotDistinctFrom {
+			return "<=>"
+		}
+	case kindSQLite:
+		if op == DistinctFrom {
+			return " IS NOT "
+		}
+		if op == NotDistinctFrom {
+			return " IS "
+		}
+	}
+	return " " + string(op) + " "
+}
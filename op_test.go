@@ -0,0 +1,86 @@
+package sqls
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseOp(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want Op
+		err  bool
+	}{
+		{"=", Eq, false},
+		{"!=", NotEq, false},
+		{">=", GtEq, false},
+		{" < ", Lt, false},
+		{"like", Like, false},
+		{"Not  Like", NotLike, false},
+		{"is distinct from", DistinctFrom, false},
+		{"", "", true},
+		{"=1 OR 1", "", true},
+		{"; DROP TABLE users; --", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOp(tt.in)
+
+			if tt.err != errors.Is(err, ErrInvalidOp) {
+				t.Errorf("invalid error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("want '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWhereExpOp(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{"postgres", PostgreSQL, `SELECT * FROM users WHERE a<>$1 AND b IS DISTINCT FROM $2 AND c IS NOT DISTINCT FROM $3 AND d NOT ILIKE $4 ESCAPE '\' AND e LIKE $5 ESCAPE '\'`},
+		{"mysql", MySQL, `SELECT * FROM users WHERE a<>? AND NOT b<=>? AND c<=>? AND LOWER(d) NOT LIKE LOWER(?) ESCAPE '\\' AND e LIKE ? ESCAPE '\\'`},
+		{"sqlite", SQLite, `SELECT * FROM users WHERE a<>@1 AND b IS NOT @2 AND c IS @3 AND LOWER(d) NOT LIKE LOWER(@4) ESCAPE '\' AND e LIKE @5 ESCAPE '\'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, _ := From("users").
+				WhereExp("a", "!=", 1).
+				WhereExp("b", string(DistinctFrom), 2).
+				WhereExp("c", string(NotDistinctFrom), 3).
+				WhereExp("d", "not ilike", "x%").
+				WhereExp("e", "like", HasPrefix("y_")).
+				ToSql()
+
+			if sql != tt.want {
+				t.Error("Invalid sql: " + sql)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		stmt := From("users").Where("id", 1).WhereExp("name", "=name OR 1=1 --", "x")
+
+		sql, args := stmt.ToSql()
+		if sql != `SELECT * FROM users WHERE id=$1 AND 1=0` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if len(args) != 1 {
+			t.Errorf("Invalid args: %v", args)
+		}
+
+		if _, _, err := stmt.Build(); !errors.Is(err, ErrInvalidOp) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+module github.com/lagasi/sqls/pgxsqls
+
+go 1.22.5
+
+require (
+	github.com/jackc/pgx/v5 v5.7.4
+	github.com/lagasi/sqls v0.0.0-20261019185318-af86add2fa7c
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+)
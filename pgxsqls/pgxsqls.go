@@ -0,0 +1,192 @@
+// Package pgxsqls runs sqls statements with pgx. Statements must be built with
+// the sqls.PostgreSQL dialect.
+package pgxsqls
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lagasi/sqls"
+)
+
+// Querier runs SQL statements. It is implemented by *pgx.Conn, *pgxpool.Pool and pgx.Tx.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+var copyThreshold atomic.Int64
+
+// SetCopyThreshold makes InsertMany load statements with at least rows rows
+// with CopyFrom. Statements with ON CONFLICT or RETURNING are always inserted.
+// The default of 0 never uses CopyFrom.
+func SetCopyThreshold(rows int) {
+	copyThreshold.Store(int64(rows))
+}
+
+// Exec builds the statement and executes it with q.
+func Exec(ctx context.Context, q Querier, stmt sqls.Statement) (pgconn.CommandTag, error) {
+	sql, args, err := stmt.Build()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	ctx, done := sqls.TraceQuery(ctx, sql, args)
+	tag, err := q.Exec(ctx, sql, args...)
+	done(tag.RowsAffected(), err)
+	return tag, err
+}
+
+// Query builds the statement and runs it with q.
+func Query(ctx context.Context, q Querier, stmt sqls.Statement) (pgx.Rows, error) {
+	sql, args, err := stmt.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := sqls.TraceQuery(ctx, sql, args)
+	rows, err := q.Query(ctx, sql, args...)
+	done(-1, err)
+	return rows, err
+}
+
+// QueryRow builds the statement and runs it with q, expecting at most one row.
+// If the statement is invalid, Scan of the row returns the error of Build.
+func QueryRow(ctx context.Context, q Querier, stmt sqls.Statement) pgx.Row {
+	sql, args, err := stmt.Build()
+	if err != nil {
+		return errRow{err: err}
+	}
+
+	ctx, done := sqls.TraceQuery(ctx, sql, args)
+	return &tracedRow{row: q.QueryRow(ctx, sql, args...), done: done}
+}
+
+// tracedRow calls the AfterQuery hooks when the row is scanned, since pgx
+// runs the query of QueryRow then.
+type tracedRow struct {
+	row  pgx.Row
+	done func(rowsAffected int64, err error)
+}
+
+func (r *tracedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if r.done != nil {
+		r.done(-1, err)
+		r.done = nil
+	}
+	return err
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
+
+// InsertMany inserts the rows of the statement and returns the number of rows
+// inserted. Statements above the parameter limit are split into chunks that
+// are sent in one batch, see SetCopyThreshold to load large statements with
+// CopyFrom instead.
+func InsertMany(ctx context.Context, q Querier, stmt *sqls.InsertManyStmt) (int64, error) {
+	if threshold := copyThreshold.Load(); threshold > 0 {
+		_, _, rows, err := stmt.CopyData()
+		if err != nil && !errors.Is(err, sqls.ErrNotSupported) {
+			return 0, err
+		}
+		if err == nil && int64(len(rows)) >= threshold {
+			return CopyFrom(ctx, q, stmt)
+		}
+	}
+
+	chunks := stmt.Chunks()
+	stmts := make([]sqls.Statement, len(chunks))
+	for i, c := range chunks {
+		stmts[i] = c
+	}
+	return execBatch(ctx, q, stmts)
+}
+
+// UpdateMany updates the rows of the statement and returns the number of rows
+// updated. Statements above the parameter limit are split into chunks that
+// are sent in one batch.
+func UpdateMany(ctx context.Context, q Querier, stmt *sqls.UpdateManyStmt) (int64, error) {
+	chunks := stmt.Chunks()
+	stmts := make([]sqls.Statement, len(chunks))
+	for i, c := range chunks {
+		stmts[i] = c
+	}
+	return execBatch(ctx, q, stmts)
+}
+
+// CopyFrom loads the rows of the statement into its table with the COPY
+// protocol and returns the number of rows copied. It returns an error wrapping
+// sqls.ErrNotSupported for statements with ON CONFLICT or RETURNING.
+func CopyFrom(ctx context.Context, q Querier, stmt *sqls.InsertManyStmt) (int64, error) {
+	table, columns, rows, err := stmt.CopyData()
+	if err != nil {
+		return 0, err
+	}
+	ident := pgx.Identifier(strings.Split(table, "."))
+	ctx, done := sqls.TraceQuery(ctx, "COPY "+ident.Sanitize()+" ("+strings.Join(columns, ",")+") FROM STDIN", nil)
+	n, err := q.CopyFrom(ctx, ident, columns, pgx.CopyFromRows(rows))
+	done(n, err)
+	return n, err
+}
+
+// execBatch executes the statements in one batch and returns the total number
+// of rows affected. A single statement is executed without a batch.
+func execBatch(ctx context.Context, q Querier, stmts []sqls.Statement) (int64, error) {
+	if len(stmts) == 1 {
+		tag, err := Exec(ctx, q, stmts[0])
+		return tag.RowsAffected(), err
+	}
+
+	batch := &pgx.Batch{}
+	for _, stmt := range stmts {
+		sql, args, err := stmt.Build()
+		if err != nil {
+			return 0, err
+		}
+		batch.Queue(sql, args...)
+	}
+
+	// the queries are traced from ctx before the batch is sent, and the batch
+	// is sent with the context of the first query, so what pgx traces while
+	// sending it belongs to a query
+	dones := make([]func(int64, error), len(batch.QueuedQueries))
+	sendCtx := ctx
+	for i, qq := range batch.QueuedQueries {
+		qctx, done := sqls.TraceQuery(ctx, qq.SQL, qq.Arguments)
+		if i == 0 {
+			sendCtx = qctx
+		}
+		dones[i] = done
+	}
+
+	results := q.SendBatch(sendCtx, batch)
+	var total int64
+	for i, done := range dones {
+		tag, err := results.Exec()
+		done(tag.RowsAffected(), err)
+		if err != nil {
+			// the following queries are not run
+			for _, done := range dones[i+1:] {
+				done(-1, err)
+			}
+			results.Close()
+			return total, err
+		}
+		total += tag.RowsAffected()
+	}
+	return total, results.Close()
+}
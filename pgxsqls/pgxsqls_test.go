@@ -0,0 +1,308 @@
+package pgxsqls
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lagasi/sqls"
+)
+
+// fakeQuerier is a local stand-in for a pgx connection that records the
+// statements it runs.
+type fakeQuerier struct {
+	traced  []any // the hookKey value of the context of each query and batch
+	queries []string
+	args    [][]any
+	batches [][]string
+	copied  [][]any
+	table   pgx.Identifier
+	columns []string
+}
+
+func (q *fakeQuerier) record(sql string, args []any) pgconn.CommandTag {
+	q.queries = append(q.queries, sql)
+	q.args = append(q.args, args)
+	return pgconn.NewCommandTag("INSERT 0 " + strconv.Itoa(strings.Count(sql, "(")-1))
+}
+
+func (q *fakeQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return q.record(sql, args), nil
+}
+
+func (q *fakeQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	q.record(sql, args)
+	return nil, nil
+}
+
+func (q *fakeQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	q.traced = append(q.traced, ctx.Value(hookKey{}))
+	q.record(sql, args)
+	return errRow{err: pgx.ErrNoRows}
+}
+
+func (q *fakeQuerier) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	q.traced = append(q.traced, ctx.Value(hookKey{}))
+	var sqls []string
+	for _, qq := range b.QueuedQueries {
+		sqls = append(sqls, qq.SQL)
+	}
+	q.batches = append(q.batches, sqls)
+	return &fakeBatchResults{queries: b.QueuedQueries}
+}
+
+func (q *fakeQuerier) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	q.table = tableName
+	q.columns = columnNames
+	for rowSrc.Next() {
+		values, err := rowSrc.Values()
+		if err != nil {
+			return 0, err
+		}
+		q.copied = append(q.copied, values)
+	}
+	return int64(len(q.copied)), rowSrc.Err()
+}
+
+type fakeBatchResults struct {
+	queries []*pgx.QueuedQuery
+}
+
+func (r *fakeBatchResults) Exec() (pgconn.CommandTag, error) {
+	qq := r.queries[0]
+	r.queries = r.queries[1:]
+	return pgconn.NewCommandTag("INSERT 0 " + strconv.Itoa(len(qq.Arguments)/2)), nil
+}
+
+func (r *fakeBatchResults) Query() (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeBatchResults) QueryRow() pgx.Row {
+	return errRow{err: errors.New("not implemented")}
+}
+
+func (r *fakeBatchResults) Close() error {
+	return nil
+}
+
+func TestExec(t *testing.T) {
+	sqls.SetDialect(sqls.PostgreSQL)
+	ctx := context.Background()
+	q := &fakeQuerier{}
+
+	if _, err := Exec(ctx, q, sqls.Update("users").Set("active", false).Where("id", 7)); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, err := Query(ctx, q, sqls.From("users").Where("active", true)); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	QueryRow(ctx, q, sqls.From("users").Where("id", 7))
+
+	want := []string{
+		"UPDATE users SET active=$1 WHERE id=$2",
+		"SELECT * FROM users WHERE active=$1",
+		"SELECT * FROM users WHERE id=$1",
+	}
+	if !reflect.DeepEqual(q.queries, want) {
+		t.Errorf("invalid queries: '%v'", q.queries)
+	}
+	if !reflect.DeepEqual(q.args, [][]any{{false, 7}, {true}, {7}}) {
+		t.Errorf("invalid args: '%v'", q.args)
+	}
+
+	if err := QueryRow(ctx, q, sqls.From("")).Scan(); !errors.Is(err, sqls.ErrNoTable) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, err := Exec(ctx, q, sqls.Delete("users")); !errors.Is(err, sqls.ErrUnscoped) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if len(q.queries) != 3 {
+		t.Errorf("invalid queries: '%v'", q.queries)
+	}
+}
+
+func TestInsertMany(t *testing.T) {
+	sqls.SetDialect(sqls.PostgreSQL)
+	ctx := context.Background()
+
+	t.Run("single statement", func(t *testing.T) {
+		q := &fakeQuerier{}
+
+		n, err := InsertMany(ctx, q, sqls.InsertMany("users").Columns("name", "age").Values("John", 30).Values("Jane", 25))
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("invalid count: %d", n)
+		}
+		if !reflect.DeepEqual(q.queries, []string{"INSERT INTO users(name,age) VALUES ($1,$2),($3,$4)"}) {
+			t.Errorf("invalid queries: '%v'", q.queries)
+		}
+		if len(q.batches) != 0 {
+			t.Errorf("invalid batches: %d", len(q.batches))
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		q := &fakeQuerier{}
+
+		// 40000 rows of 2 columns exceed the 65535 parameters of PostgreSQL
+		stmt := sqls.InsertMany("users").Columns("name", "age")
+		for i := range 40000 {
+			stmt.Values("John", i)
+		}
+
+		n, err := InsertMany(ctx, q, stmt)
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if n != 40000 {
+			t.Errorf("invalid count: %d", n)
+		}
+		if len(q.batches) != 1 || len(q.batches[0]) != 2 {
+			t.Fatalf("invalid batches: %d", len(q.batches))
+		}
+		if !strings.HasSuffix(q.batches[0][1], "($14465,$14466)") {
+			t.Errorf("invalid sql: '%s'", q.batches[0][1][len(q.batches[0][1])-40:])
+		}
+	})
+
+	t.Run("copy threshold", func(t *testing.T) {
+		SetCopyThreshold(2)
+		defer SetCopyThreshold(0)
+		q := &fakeQuerier{}
+
+		n, err := InsertMany(ctx, q, sqls.InsertMany("app.users").Columns("name", "age").Values("John", 30).Values("Jane", 25))
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if n != 2 || len(q.queries) != 0 {
+			t.Errorf("invalid count: %d", n)
+		}
+		if !reflect.DeepEqual(q.table, pgx.Identifier{"app", "users"}) {
+			t.Errorf("invalid table: '%v'", q.table)
+		}
+		if !reflect.DeepEqual(q.columns, []string{"name", "age"}) {
+			t.Errorf("invalid columns: '%v'", q.columns)
+		}
+		if !reflect.DeepEqual(q.copied, [][]any{{"John", 30}, {"Jane", 25}}) {
+			t.Errorf("invalid rows: '%v'", q.copied)
+		}
+
+		// statements with ON CONFLICT are inserted
+		q = &fakeQuerier{}
+		stmt := sqls.InsertMany("users").Columns("name", "age").Values("John", 30).Values("Jane", 25).OnConflict("DO NOTHING")
+		if _, err := InsertMany(ctx, q, stmt); err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if len(q.queries) != 1 || q.copied != nil {
+			t.Errorf("invalid queries: '%v'", q.queries)
+		}
+	})
+
+	t.Run("invalid statement", func(t *testing.T) {
+		q := &fakeQuerier{}
+
+		_, err := InsertMany(ctx, q, sqls.InsertMany("users").Columns("name", "age").Values("John"))
+		if !errors.Is(err, sqls.ErrValueCount) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if _, err := CopyFrom(ctx, q, sqls.InsertMany("users").Columns("name").Values("John").Returning("id")); !errors.Is(err, sqls.ErrNotSupported) {
+			t.Errorf("invalid error: %v", err)
+		}
+		if len(q.queries) != 0 || q.copied != nil {
+			t.Errorf("invalid queries: '%v'", q.queries)
+		}
+	})
+}
+
+func TestUpdateMany(t *testing.T) {
+	sqls.SetDialect(sqls.PostgreSQL)
+	q := &fakeQuerier{}
+
+	_, err := UpdateMany(context.Background(), q, sqls.UpdateMany("users").Key("id").Columns("name").Types("int", "text").Values(1, "John"))
+	if err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if !reflect.DeepEqual(q.queries, []string{"UPDATE users SET name=v.name FROM (VALUES ($1::int,$2::text)) AS v(id,name) WHERE users.id=v.id"}) {
+		t.Errorf("invalid queries: '%v'", q.queries)
+	}
+}
+
+type hookKey struct{}
+
+type recordHook struct {
+	started int
+	queries []string
+	rows    []int64
+	errs    []error
+}
+
+func (h *recordHook) BeforeQuery(ctx context.Context, q *sqls.QueryInfo) context.Context {
+	h.started++
+	return context.WithValue(ctx, hookKey{}, h.started)
+}
+
+func (h *recordHook) AfterQuery(ctx context.Context, q *sqls.QueryInfo) {
+	h.queries = append(h.queries, q.SQL)
+	h.rows = append(h.rows, q.RowsAffected)
+	h.errs = append(h.errs, q.Err)
+}
+
+func TestHooks(t *testing.T) {
+	sqls.SetDialect(sqls.PostgreSQL)
+	defer sqls.SetHooks()
+	defer SetCopyThreshold(0)
+	ctx := context.Background()
+
+	h := &recordHook{}
+	sqls.SetHooks(h)
+	q := &fakeQuerier{}
+
+	stmt := sqls.InsertMany("users").Columns("id", "name")
+	for i := range 40000 {
+		stmt.Values(i, "name")
+	}
+	if _, err := InsertMany(ctx, q, stmt); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	SetCopyThreshold(1)
+	if _, err := InsertMany(ctx, q, sqls.InsertMany("app.users").Columns("id", "name").Values(1, "John")); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	if len(h.queries) != 3 {
+		t.Fatalf("invalid queries: %d", len(h.queries))
+	}
+	if !reflect.DeepEqual(h.rows, []int64{32767, 7233, 1}) {
+		t.Errorf("invalid rows: '%v'", h.rows)
+	}
+	if h.queries[2] != `COPY "app"."users" (id,name) FROM STDIN` {
+		t.Errorf("invalid sql: '%s'", h.queries[2])
+	}
+	// the batch is sent with the context of its first query
+	if !reflect.DeepEqual(q.traced, []any{1}) {
+		t.Errorf("invalid contexts: '%v'", q.traced)
+	}
+
+	row := QueryRow(ctx, q, sqls.From("users").Where("id", 7))
+	if len(h.queries) != 3 {
+		t.Errorf("query done before Scan: %d", len(h.queries))
+	}
+	if err := row.Scan(); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("invalid error: %v", err)
+	}
+	row.Scan()
+	if len(h.queries) != 4 || !errors.Is(h.errs[3], pgx.ErrNoRows) {
+		t.Errorf("invalid queries: '%v' '%v'", h.queries, h.errs)
+	}
+	if !reflect.DeepEqual(q.traced, []any{1, 4}) {
+		t.Errorf("invalid contexts: '%v'", q.traced)
+	}
+}
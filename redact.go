@@ -0,0 +1,254 @@
+package sqls
+
+import "strings"
+
+// Redacted replaces the arguments hidden by a Redactor.
+const Redacted = "[REDACTED]"
+
+// Redactor hides sensitive arguments, such as passwords and tokens, from the
+// output of hooks.
+type Redactor struct {
+	// Positions are the positions of the arguments to hide, starting at 1.
+	Positions []int
+	// Columns are the columns whose arguments are hidden. They are matched
+	// without case and table name to the columns of comparisons such as
+	// token=$1, assignments such as SET password=$1, INSERT column lists and
+	// the rows of UpdateMany statements. The arguments whose column cannot be
+	// found in the query are hidden too.
+	Columns []string
+}
+
+// Args returns a copy of the arguments of the query with the redacted
+// arguments replaced by Redacted.
+func (r *Redactor) Args(sql string, args []any) []any {
+	list := make([]any, len(args))
+	copy(list, args)
+
+	for _, p := range r.Positions {
+		if p >= 1 && p <= len(list) {
+			list[p-1] = Redacted
+		}
+	}
+	if len(r.Columns) == 0 {
+		return list
+	}
+
+	for i, c := range argColumns(sql, len(args)) {
+		if c == "" {
+			list[i] = Redacted
+		}
+		for _, rc := range r.Columns {
+			if strings.EqualFold(c, rc) {
+				list[i] = Redacted
+			}
+		}
+	}
+	return list
+}
+
+// queryDialect returns the dialect whose placeholders are found the most in
+// the query, preferring the current one, since a statement keeps the dialect
+// it was built with.
+func queryDialect(sql string, n int) *Dialect {
+	best, found := curDialect.Load(), -1
+	for _, d := range []*Dialect{best, &PostgreSQL, &DefaultDialect, &MySQL} {
+		if _, refs := d.splitParams(sql, n); len(refs) > found {
+			best, found = d, len(refs)
+		}
+	}
+	return best
+}
+
+// argColumns returns the column of each argument of the query, or "" if it
+// cannot be found.
+func argColumns(sql string, n int) []string {
+	d := queryDialect(sql, n)
+	columns := make([]string, n)
+	parts, refs := d.splitParams(sql, n)
+	rows, start, open := rowColumns(sql)
+
+	pos, k, prev := 0, 0, ""
+	var row []string
+	between := false
+	for i, ref := range refs {
+		pos += len(parts[i])
+		part := strings.TrimRight(parts[i], " ")
+		upper := strings.ToUpper(part)
+		c := ""
+		switch {
+		case rows != nil && pos > start:
+			if strings.HasSuffix(part, open) {
+				k = 0
+			} else {
+				k++
+			}
+			if k < len(rows) {
+				c = rows[k]
+			}
+		case part == ",":
+			// the following values of an IN list or of a row value
+			k++
+			c = prev
+			if row != nil {
+				c = ""
+				if k < len(row) {
+					c = row[k]
+				}
+			}
+		case between && strings.TrimSpace(upper) == "AND":
+			c = prev
+		default:
+			row, k = nil, 0
+			if row = rowValue(part); row != nil {
+				c = row[0]
+			} else if c = caseColumn(sql[:pos], parts[i]); c == "" {
+				c = columnBefore(part)
+			}
+		}
+		between = strings.HasSuffix(upper, " BETWEEN")
+
+		c = strings.Trim(c, "`\"[] ")
+		if dot := strings.LastIndexByte(c, '.'); dot >= 0 {
+			c = c[dot+1:]
+		}
+		if strings.ContainsAny(c, "()=<>!,' ") {
+			c = ""
+		}
+		columns[ref] = c
+		prev = c
+
+		// skip the placeholder
+		pos += len(d.placeholder)
+		for pos < len(sql) && sql[pos] >= '0' && sql[pos] <= '9' {
+			pos++
+		}
+	}
+	return columns
+}
+
+// rowValue returns the columns of a row value comparison such as
+// (id,token)>($1,$2) when s is the query up to the first value.
+func rowValue(s string) []string {
+	t, ok := strings.CutSuffix(s, "(")
+	if !ok {
+		return nil
+	}
+	t = strings.TrimRight(t, " =<>!")
+	open := strings.LastIndexByte(t, '(')
+	if len(t) == len(s)-1 || !strings.HasSuffix(t, ")") || open < 0 {
+		return nil
+	}
+	if open > 0 && !strings.ContainsAny(t[open-1:open], " (,") {
+		// a function call such as LOWER(name)=(
+		return nil
+	}
+	return strings.Split(t[open+1:len(t)-1], ",")
+}
+
+// rowColumns returns the columns of the rows of values of an INSERT or of an
+// UPDATE of many rows, the offset where the rows start and the text opening a
+// row. The values are assigned to the columns by their position in the row.
+func rowColumns(sql string) ([]string, int, string) {
+	upper := strings.ToUpper(sql)
+	switch {
+	case strings.HasPrefix(upper, "INSERT INTO "):
+		open, end := strings.IndexByte(sql, '('), strings.IndexByte(sql, ')')
+		values := strings.Index(upper, " VALUES ")
+		if open >= 0 && end > open && values > end {
+			return strings.Split(sql[open+1:end], ","), values, "("
+		}
+	case strings.HasPrefix(upper, "UPDATE "):
+		// (VALUES ($1,$2),($3,$4)) AS v(id,c) on PostgreSQL and SQL Server
+		values, alias := strings.Index(upper, "(VALUES ("), strings.Index(upper, ") AS V(")
+		if values >= 0 && alias > values {
+			list := sql[alias+len(") AS v("):]
+			if end := strings.IndexByte(list, ')'); end >= 0 {
+				return strings.Split(list[:end], ","), values, "("
+			}
+		}
+		// (SELECT $1 AS id,$2 AS c UNION ALL SELECT $3,$4) AS v on MySQL
+		sel := strings.Index(upper, " JOIN (SELECT ")
+		if sel >= 0 && strings.Contains(upper, ") AS V ON ") {
+			first := sql[sel+len(" JOIN (SELECT "):]
+			if end := strings.Index(strings.ToUpper(first), " UNION ALL "); end >= 0 {
+				first = first[:end]
+			} else if end := strings.Index(strings.ToUpper(first), ") AS V ON "); end >= 0 {
+				first = first[:end]
+			}
+			items := strings.Split(first, ",")
+			columns := make([]string, len(items))
+			for i, item := range items {
+				_, columns[i], _ = strings.Cut(item, " AS ")
+			}
+			return columns, sel, "SELECT"
+		}
+	}
+	return nil, -1, ""
+}
+
+// caseColumn returns the column of the value after part in
+// c=CASE id WHEN $1 THEN $2 END: the key column after WHEN and the assigned
+// column after THEN. before is the query up to the value.
+func caseColumn(before string, part string) string {
+	t := strings.ToUpper(strings.TrimRight(part, " "))
+	when, then := strings.HasSuffix(t, " WHEN"), strings.HasSuffix(t, " THEN")
+	if !when && !then {
+		return ""
+	}
+	i := strings.LastIndex(strings.ToUpper(before), "=CASE ")
+	if i < 0 {
+		return ""
+	}
+	if when {
+		key, _, _ := strings.Cut(before[i+len("=CASE "):], " ")
+		return key
+	}
+	return columnBefore(before[:i])
+}
+
+// columnBefore returns the column compared to or assigned the value after s,
+// for example "id" for "WHERE id=" and "name" for "AND name NOT LIKE ". A
+// column in LOWER() or UPPER() and a value in them, in ANY() or in ALL() are
+// found, the columns of other expressions are not.
+func columnBefore(s string) string {
+	for {
+		if t, ok := strings.CutSuffix(s, "("); ok {
+			t = strings.TrimRight(t, " ")
+			fn := t[strings.LastIndexAny(t, " ,(=<>!")+1:]
+			switch strings.ToUpper(fn) {
+			case "LOWER", "UPPER", "ANY", "ALL":
+				s = t[:len(t)-len(fn)]
+				continue
+			case "IN", "":
+				s = t
+			default:
+				return ""
+			}
+		}
+		t := strings.TrimRight(s, " =<>!,")
+		if inner, ok := strings.CutSuffix(t, ")"); ok {
+			// LOWER(column)
+			open := strings.LastIndexByte(inner, '(')
+			if open < 0 {
+				return ""
+			}
+			switch strings.ToUpper(inner[strings.LastIndexAny(inner[:open], " ,(=<>!")+1 : open]) {
+			case "LOWER", "UPPER":
+				return inner[open+1:]
+			}
+			return ""
+		}
+		word := t[strings.LastIndexAny(t, " ,(")+1:]
+		switch strings.ToUpper(word) {
+		case "LIKE", "ILIKE", "NOT", "IS", "DISTINCT", "FROM", "IN", "BETWEEN", "AND":
+			s = t[:len(t)-len(word)]
+			continue
+		case "WHERE", "OR", "ON", "SET", "SELECT", "HAVING", "CASE", "WHEN", "THEN", "ELSE", "VALUES", "LIMIT", "OFFSET":
+			return ""
+		}
+		if word == "" || strings.ContainsAny(word, "()=<>!,'") {
+			return ""
+		}
+		return word
+	}
+}
@@ -0,0 +1,102 @@
+package sqls
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	var tests = []struct {
+		name     string
+		redactor Redactor
+		sql      string
+		args     []any
+		want     []any
+	}{
+		{"positions", Redactor{Positions: []int{2, 5}},
+			"SELECT * FROM users WHERE a=$1 AND b=$2", []any{1, 2}, []any{1, Redacted}},
+		{"where", Redactor{Columns: []string{"token"}},
+			"SELECT * FROM sessions s WHERE s.user_id=$1 AND s.token=$2", []any{7, "abc"}, []any{7, Redacted}},
+		{"set", Redactor{Columns: []string{"PASSWORD"}},
+			"UPDATE users SET name=$1,password=$2 WHERE id=$3", []any{"John", "s3cret", 7}, []any{"John", Redacted, 7}},
+		{"insert", Redactor{Columns: []string{"password"}},
+			"INSERT INTO users(name,password) VALUES ($1,$2),($3,$4) RETURNING id", []any{"John", "a", "Jane", "b"}, []any{"John", Redacted, "Jane", Redacted}},
+		{"insert spaces", Redactor{Columns: []string{"password"}},
+			"INSERT INTO users (name,password) VALUES ($1,$2)", []any{"John", "a"}, []any{"John", Redacted}},
+		{"in and between", Redactor{Columns: []string{"code", "pin"}},
+			"SELECT * FROM t WHERE code IN ($1,$2) AND pin BETWEEN $3 AND $4 AND id=$5", []any{1, 2, 3, 4, 5}, []any{Redacted, Redacted, Redacted, Redacted, 5}},
+		{"like", Redactor{Columns: []string{"secret"}},
+			"SELECT * FROM t WHERE secret NOT LIKE $1 ESCAPE '\\' AND name LIKE $2", []any{"a%", "b%"}, []any{Redacted, "b%"}},
+		{"quoted", Redactor{Columns: []string{"note"}},
+			`SELECT * FROM t WHERE "note"=$1 AND x='$2' AND y=$2`, []any{"a", "b"}, []any{Redacted, "b"}},
+		{"lower", Redactor{Columns: []string{"password"}},
+			`SELECT * FROM users WHERE LOWER(password) LIKE LOWER(@1) ESCAPE '\' AND LOWER(name) LIKE LOWER(@2) ESCAPE '\'`, []any{"a", "b"}, []any{Redacted, "b"}},
+		{"row value", Redactor{Columns: []string{"token"}},
+			"SELECT * FROM t WHERE (id,token)>($1,$2) AND (a, b) = ($3, $4)", []any{1, "abc", 3, 4}, []any{1, Redacted, 3, 4}},
+		{"any", Redactor{Columns: []string{"token"}},
+			"SELECT * FROM t WHERE token=ANY($1) AND id<>ALL($2)", []any{[]string{"abc"}, []int{1}}, []any{Redacted, []int{1}}},
+		{"mismatch", Redactor{Columns: []string{"token"}},
+			"SELECT * FROM t WHERE id=? AND token=?", []any{1, "abc"}, []any{1, Redacted}},
+		{"unknown", Redactor{Columns: []string{"token"}},
+			"SELECT * FROM t WHERE id=$1 AND COALESCE(token,$2)=$3 AND $4", []any{1, "a", "b", "c"}, []any{1, Redacted, Redacted, Redacted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.redactor.Args(tt.sql, tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// a statement keeps its dialect when the current one changes
+	q := From("users").WhereILike("password", "a").WhereRow([]string{"id", "token"}, ">", []any{1, "b"}).WhereAny("key", []string{"c"})
+	SetDialect(SQLite)
+	sql, args := q.ToSql()
+	got := (&Redactor{Columns: []string{"password", "token", "key"}}).Args(sql, args)
+	if want := []any{Redacted, 1, Redacted, Redacted}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v for '%s'", want, got, sql)
+	}
+	SetDialect(PostgreSQL)
+
+	args = []any{"s3cret"}
+	(&Redactor{Positions: []int{1}}).Args("SELECT $1", args)
+	if args[0] != "s3cret" {
+		t.Error("arguments changed")
+	}
+}
+
+func TestRedactorUpdateMany(t *testing.T) {
+	defer SetDialect(DefaultDialect)
+
+	var dialects = []struct {
+		name    string
+		dialect Dialect
+		want    []any
+	}{
+		{"postgres", PostgreSQL, []any{1, "John", Redacted, 2, "Jane", Redacted}},
+		{"mysql", MySQL, []any{1, "John", Redacted, 2, "Jane", Redacted}},
+		{"sql server", SQLServer, []any{1, "John", Redacted, 2, "Jane", Redacted}},
+		{"sqlite", SQLite, []any{1, "John", 2, "Jane", Redacted, Redacted}},
+		{"default", DefaultDialect, []any{1, "John", 2, "Jane", Redacted, Redacted}},
+	}
+
+	redactor := Redactor{Columns: []string{"password"}}
+	for _, d := range dialects {
+		t.Run(d.name, func(t *testing.T) {
+			SetDialect(d.dialect)
+			sql, args := UpdateMany("users").Key("id").Columns("name", "password").Types("int", "text", "text").
+				Values(1, "John", "a").
+				Values(2, "Jane", "b").
+				ToSql()
+
+			got := redactor.Args(sql, args)
+			if !reflect.DeepEqual(got, d.want) {
+				t.Errorf("want %v, got %v for '%s'", d.want, got, sql)
+			}
+		})
+	}
+}
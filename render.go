@@ -0,0 +1,140 @@
+package sqls
+
+import (
+	"strconv"
+	"sync"
+)
+
+// bufPool holds the buffers statements are rendered into by ToSql and Build,
+// so rendering a statement only allocates the returned string.
+var bufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// maxPooledBuf keeps buffers of very large statements out of the pool.
+const maxPooledBuf = 64 << 10
+
+func getBuf() *[]byte {
+	bp := bufPool.Get().(*[]byte)
+	*bp = (*bp)[:0]
+	return bp
+}
+
+// bufString returns the rendered statement and puts its buffer back into the pool.
+func bufString(bp *[]byte, b []byte) string {
+	query := string(b)
+	if cap(b) <= maxPooledBuf {
+		*bp = b[:0]
+		bufPool.Put(bp)
+	}
+	return query
+}
+
+// render renders a statement with the dialect into a pooled buffer and
+// returns its SQL and arguments, see Dialect.bindOrder.
+func render(d *Dialect, appendSql func(b []byte, d *Dialect) []byte, args []any) (string, []any) {
+	bp := getBuf()
+	b, args := d.bindOrder(appendSql(*bp, d), 0, args)
+	return bufString(bp, b), args
+}
+
+// appendList appends the items separated by sep.
+func appendList(b []byte, list []string, sep string) []byte {
+	for i, item := range list {
+		if i > 0 {
+			b = append(b, sep...)
+		}
+		b = append(b, item...)
+	}
+	return b
+}
+
+func (j join) appendSql(b []byte) []byte {
+	b = append(b, "JOIN "...)
+	b = append(b, j.table...)
+	b = append(b, " ON "...)
+	return appendList(b, j.on, " AND ")
+}
+
+func appendJoins(b []byte, joins []join) []byte {
+	for _, j := range joins {
+		b = append(b, ' ')
+		b = j.appendSql(b)
+	}
+	return b
+}
+
+// appendFrom appends additional tables and joins as a FROM or USING list.
+// PostgreSQL does not allow the ON condition of a join to refer to the table
+// being updated or deleted from, so the joined tables are listed like the
+// additional tables and the ON conditions of all joins are returned so they can
+// be added to the WHERE clause. Joins are inner joins, so the rows are the same.
+func appendFrom(b []byte, tables []string, joins []join) ([]byte, []string) {
+	var conds []string
+
+	b = appendList(b, tables, ",")
+	for i, j := range joins {
+		if i > 0 || len(tables) > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, j.table...)
+		conds = append(conds, j.on...)
+	}
+	return b, conds
+}
+
+// appendTables appends a leading table followed by additional tables and joins.
+func appendTables(b []byte, table string, tables []string, joins []join) []byte {
+	b = append(b, table...)
+	for _, t := range tables {
+		b = append(b, ',')
+		b = append(b, t...)
+	}
+	return appendJoins(b, joins)
+}
+
+// appendWhere appends the WHERE clause for the conditions of all lists.
+func appendWhere(b []byte, where ...[]string) []byte {
+	first := true
+	for _, conds := range where {
+		for _, c := range conds {
+			if first {
+				b = append(b, " WHERE "...)
+				first = false
+			} else {
+				b = append(b, " AND "...)
+			}
+			b = append(b, c...)
+		}
+	}
+	return b
+}
+
+// appendOrderLimit appends the ORDER BY and LIMIT clauses.
+func appendOrderLimit(b []byte, orderBy []string, limit int) []byte {
+	if orderBy != nil {
+		b = append(b, " ORDER BY "...)
+		b = appendList(b, orderBy, ",")
+	}
+	if limit > 0 {
+		b = append(b, " LIMIT "...)
+		b = strconv.AppendInt(b, int64(limit), 10)
+	}
+	return b
+}
+
+// appendConflictReturning appends the ON CONFLICT and RETURNING clauses of an INSERT statement.
+func appendConflictReturning(b []byte, conflict string, returning []string) []byte {
+	if conflict != "" {
+		b = append(b, " ON CONFLICT "...)
+		b = append(b, conflict...)
+	}
+	if returning != nil {
+		b = append(b, " RETURNING "...)
+		b = appendList(b, returning, ",")
+	}
+	return b
+}
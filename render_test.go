@@ -0,0 +1,63 @@
+package sqls
+
+import "testing"
+
+func BenchmarkRender(b *testing.B) {
+	SetDialect(PostgreSQL)
+	defer SetDialect(DefaultDialect)
+
+	insertMany := InsertMany("users").Columns("name", "age")
+	updateMany := UpdateMany("users").Key("id").Columns("name", "age").Types("int", "text", "int")
+	for i := range 100 {
+		insertMany.Values("John", i)
+		updateMany.Values(i, "John", 30)
+	}
+
+	var stmts = []struct {
+		name string
+		stmt interface {
+			ToSql() (string, []any)
+			AppendSql(buf []byte) ([]byte, []any)
+		}
+	}{
+		{"Select", From("users u").
+			Select("u.id", "u.name", "count(o.id)").
+			Join("orders o", "o.user_id", "u.id").
+			Where("u.active", true).
+			WhereIn("u.role", []any{"admin", "staff"}).
+			GroupBy("u.id", "u.name").
+			OrderBy("u.name").
+			Limit(20)},
+		{"Insert", Insert("users").
+			Set("email", "fake@email.com").
+			Set("password", "l33tP@$$w0rd").
+			Set("active", true).
+			Returning("id")},
+		{"InsertMany", insertMany},
+		{"Update", Update("users").
+			Set("name", "John").
+			Set("active", true).
+			Where("id", 123).
+			WhereNotNull("email")},
+		{"UpdateMany", updateMany},
+		{"Delete", Delete("sessions").
+			Where("user_id", 123).
+			WhereExp("expires_at", "<", "2024-01-01")},
+	}
+
+	for _, st := range stmts {
+		b.Run(st.name+"/ToSql", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				st.stmt.ToSql()
+			}
+		})
+		b.Run(st.name+"/AppendSql", func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 4096)
+			for range b.N {
+				buf, _ = st.stmt.AppendSql(buf[:0])
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package sqls
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structMap maps the columns of a struct type to the index paths of its fields.
+type structMap struct {
+	columns []string
+	fields  map[string][]int
+}
+
+// structMaps caches the structMap of each struct type.
+var structMaps sync.Map
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+// All runs the statement with db and scans every row into a T. If T is a
+// struct, columns are matched to fields by their db tag, or by their
+// lowercased name when they have none, fields tagged db:"-" are skipped and
+// the fields of embedded structs are included, except for pointers to
+// unexported struct types, which cannot be allocated. A SELECT statement without
+// columns selects the columns of T. Other types of T are scanned from a
+// single column.
+func All[T any](ctx context.Context, db Executor, stmt Statement) ([]T, error) {
+	rows, err := query(ctx, db, selectFields[T](stmt))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scan, err := rowScanner[T](rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []T
+	for rows.Next() {
+		var v T
+		if err := scan(&v); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// One is like All but scans only the first row. It returns sql.ErrNoRows when
+// the statement returns no rows.
+func One[T any](ctx context.Context, db Executor, stmt Statement) (T, error) {
+	var v T
+
+	rows, err := query(ctx, db, selectFields[T](stmt))
+	if err != nil {
+		return v, err
+	}
+	defer rows.Close()
+
+	scan, err := rowScanner[T](rows)
+	if err != nil {
+		return v, err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return v, err
+		}
+		return v, sql.ErrNoRows
+	}
+	if err := scan(&v); err != nil {
+		return v, err
+	}
+	return v, rows.Close()
+}
+
+// selectFields returns a SELECT statement without columns with the columns of
+// T selected, any other statement is returned as it is. The columns are
+// qualified with the table when the statement has joins, since the joined
+// tables may have columns of the same name.
+func selectFields[T any](stmt Statement) Statement {
+	var s *SelectStmt
+	switch x := stmt.(type) {
+	case *SelectStmt:
+		s = x
+	case SelectQuery:
+		s = x.get()
+	default:
+		return stmt
+	}
+
+	t := reflect.TypeFor[T]()
+	if s.columns != nil || !isStruct(t) {
+		return stmt
+	}
+
+	c := *s
+	c.columns = typeMap(t).columns
+	if len(s.joins) > 0 {
+		alias := tableAlias(s.table)
+		c.columns = make([]string, len(c.columns))
+		for i, column := range typeMap(t).columns {
+			c.columns[i] = alias + "." + column
+		}
+	}
+	return &c
+}
+
+// isStruct reports whether values of t are scanned field by field.
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeFor[time.Time]() && !reflect.PointerTo(t).Implements(scannerType)
+}
+
+// rowScanner returns a function that scans the current row into a T.
+func rowScanner[T any](rows *sql.Rows) (func(v *T) error, error) {
+	t := reflect.TypeFor[T]()
+	if !isStruct(t) {
+		return func(v *T) error { return rows.Scan(v) }, nil
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	m := typeMap(t)
+	paths := make([][]int, len(columns))
+	for i, c := range columns {
+		path, ok := m.fields[strings.ToLower(c)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q in %s", ErrNoField, c, t)
+		}
+		paths[i] = path
+	}
+
+	dest := make([]any, len(columns))
+	return func(v *T) error {
+		rv := reflect.ValueOf(v).Elem()
+		for i, path := range paths {
+			dest[i] = fieldByIndex(rv, path).Addr().Interface()
+		}
+		return rows.Scan(dest...)
+	}, nil
+}
+
+// fieldByIndex returns the field with the index path, allocating nil embedded
+// struct pointers on the way.
+func fieldByIndex(v reflect.Value, path []int) reflect.Value {
+	for i, x := range path {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// typeMap returns the cached structMap of the struct type t.
+func typeMap(t reflect.Type) *structMap {
+	if m, ok := structMaps.Load(t); ok {
+		return m.(*structMap)
+	}
+
+	m := &structMap{fields: map[string][]int{}}
+	m.add(t, nil)
+	cached, _ := structMaps.LoadOrStore(t, m)
+	return cached.(*structMap)
+}
+
+func (m *structMap) add(t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("db")
+		// promoted fields of an unexported embedded struct pointer cannot be allocated
+		if tag == "-" || (!f.IsExported() && !(f.Anonymous && f.Type.Kind() == reflect.Struct)) {
+			continue
+		}
+		path := append(index[:len(index):len(index)], i)
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && tag == "" && isStruct(ft) {
+			m.add(ft, path)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		key := strings.ToLower(name)
+
+		// fields of the outer struct hide the fields of embedded structs
+		prev, ok := m.fields[key]
+		if ok && len(prev) <= len(path) {
+			continue
+		}
+		if !ok {
+			m.columns = append(m.columns, name)
+		}
+		m.fields[key] = path
+	}
+}
@@ -0,0 +1,149 @@
+package sqls
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testBase struct {
+	ID      int       `db:"id"`
+	Created time.Time `db:"created_at"`
+}
+
+type TestAudit struct {
+	By string `db:"updated_by"`
+}
+
+type testUser struct {
+	testBase
+	*TestAudit
+	Name     string
+	Email    *string        `db:"email"`
+	Nickname sql.NullString `db:"nickname"`
+	Password string         `db:"-"`
+	secret   string
+}
+
+func TestAll(t *testing.T) {
+	SetDialect(PostgreSQL)
+	ctx := context.Background()
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	db, tdb := openTestDB(t,
+		[]string{"id", "created_at", "updated_by", "name", "email", "nickname"},
+		[]driver.Value{int64(1), created, "admin", "John", "john@example.com", nil},
+		[]driver.Value{int64(2), created, "admin", "Jane", nil, "jj"},
+	)
+
+	users, err := All[testUser](ctx, db, From("users").Where("active", true))
+	if err != nil {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	query, args := tdb.last()
+	if query != "SELECT id,created_at,updated_by,name,email,nickname FROM users WHERE active=$1" {
+		t.Errorf("invalid sql: '%s'", query)
+	}
+	if !reflect.DeepEqual(args, []any{true}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+
+	email := "john@example.com"
+	want := []testUser{
+		{testBase: testBase{1, created}, TestAudit: &TestAudit{"admin"}, Name: "John", Email: &email},
+		{testBase: testBase{2, created}, TestAudit: &TestAudit{"admin"}, Name: "Jane", Nickname: sql.NullString{String: "jj", Valid: true}},
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("invalid users: '%+v'", users)
+	}
+}
+
+func TestAllSelect(t *testing.T) {
+	SetDialect(PostgreSQL)
+	ctx := context.Background()
+
+	db, tdb := openTestDB(t, []string{"ID", "Name"}, []driver.Value{int64(1), "John"})
+
+	stmt := From("users").Select("id", "name")
+	users, err := All[testUser](ctx, db, stmt)
+	if err != nil {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if query, _ := tdb.last(); query != "SELECT id,name FROM users" {
+		t.Errorf("invalid sql: '%s'", query)
+	}
+	if len(users) != 1 || users[0].ID != 1 || users[0].Name != "John" || users[0].TestAudit != nil {
+		t.Errorf("invalid users: '%+v'", users)
+	}
+
+	// the statement is not changed by selecting the columns of a struct
+	base := From("users")
+	if _, err := All[testUser](ctx, db, base); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if sql, _ := base.ToSql(); sql != "SELECT * FROM users" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+
+	// the columns of a struct are qualified with the table of a join
+	joined := From("users u").Join("roles r", "r.user_id", "u.id").Where("r.role", "admin")
+	if _, err := All[testUser](ctx, db, joined); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if query, _ := tdb.last(); query != "SELECT u.id,u.created_at,u.updated_by,u.name,u.email,u.nickname FROM users u JOIN roles r ON r.user_id=u.id WHERE r.role=$1" {
+		t.Errorf("invalid sql: '%s'", query)
+	}
+}
+
+func TestOne(t *testing.T) {
+	SetDialect(PostgreSQL)
+	ctx := context.Background()
+
+	t.Run("struct", func(t *testing.T) {
+		db, _ := openTestDB(t, []string{"id", "name"}, []driver.Value{int64(1), "John"}, []driver.Value{int64(2), "Jane"})
+
+		user, err := One[testUser](ctx, db, From("users").Select("id", "name").Immutable())
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if user.ID != 1 || user.Name != "John" {
+			t.Errorf("invalid user: '%+v'", user)
+		}
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		db, tdb := openTestDB(t, []string{"count"}, []driver.Value{int64(42)})
+
+		count, err := One[int](ctx, db, From("users").Count())
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if count != 42 {
+			t.Errorf("invalid count: %d", count)
+		}
+		if query, _ := tdb.last(); query != "SELECT COUNT(*) FROM users" {
+			t.Errorf("invalid sql: '%s'", query)
+		}
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		db, _ := openTestDB(t, []string{"id"})
+
+		if _, err := One[testUser](ctx, db, From("users")); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		db, _ := openTestDB(t, []string{"id", "age"}, []driver.Value{int64(1), int64(30)})
+
+		if _, err := One[testUser](ctx, db, From("users")); !errors.Is(err, ErrNoField) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
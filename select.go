@@ -1,6 +1,7 @@
 package sqls
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -12,10 +13,12 @@ type SelectStmt struct {
 	table   string
 	columns []string
 	whereClause
-	joins   []string
-	groupBy string
-	having  string
-	orderBy string
+	joins   []join
+	groupBy []string
+	having  []string
+	orderBy []string
+	// sub is the subquery counted by a statement derived with Count
+	sub *SelectStmt
 }
 
 // From creates a new SELECT statement.
@@ -37,6 +40,12 @@ func (s *SelectStmt) Where(column string, value any) *SelectStmt {
 	return s
 }
 
+// WhereNotEquals adds a WHERE column<>value clause to the SELECT statement.
+func (s *SelectStmt) WhereNotEquals(column string, value any) *SelectStmt {
+	s.whereNotEquals(column, value)
+	return s
+}
+
 // WhereNull adds a WHERE column IS NULL clause to the SELECT statement.
 func (s *SelectStmt) WhereNull(column string) *SelectStmt {
 	s.whereNull(column)
@@ -49,7 +58,9 @@ func (s *SelectStmt) WhereNotNull(column string) *SelectStmt {
 	return s
 }
 
-// WhereExp adds a WHERE column expression value clause to the SELECT statement.
+// WhereExp adds a WHERE column operator value clause to the SELECT statement.
+// The operator must be one of the Op constants, see ParseOp. LIKE operators get
+// the ESCAPE clause of WhereLike.
 func (s *SelectStmt) WhereExp(column string, ex string, value any) *SelectStmt {
 	s.whereExp(column, ex, value)
 	return s
@@ -61,6 +72,84 @@ func (s *SelectStmt) WhereIn(column string, values []any) *SelectStmt {
 	return s
 }
 
+// WhereNotIn adds a WHERE column NOT IN (values) clause to the SELECT statement.
+func (s *SelectStmt) WhereNotIn(column string, values []any) *SelectStmt {
+	s.whereNotIn(column, values)
+	return s
+}
+
+// WhereAny adds a WHERE column=ANY(values) clause to the SELECT statement. The slice
+// is bound as a single array argument on PostgreSQL and expanded into a WHERE
+// column IN (values) clause on other dialects.
+func (s *SelectStmt) WhereAny(column string, values any) *SelectStmt {
+	s.whereAny(column, values)
+	return s
+}
+
+// WhereBetween adds a WHERE column BETWEEN from AND to clause to the SELECT statement.
+func (s *SelectStmt) WhereBetween(column string, from any, to any) *SelectStmt {
+	s.whereBetween(column, ` BETWEEN `, from, to)
+	return s
+}
+
+// WhereNotBetween adds a WHERE column NOT BETWEEN from AND to clause to the SELECT statement.
+func (s *SelectStmt) WhereNotBetween(column string, from any, to any) *SelectStmt {
+	s.whereBetween(column, ` NOT BETWEEN `, from, to)
+	return s
+}
+
+// WhereLike adds a WHERE column LIKE value clause to the SELECT statement.
+// The % and _ wildcards in value are escaped so it matches literally, use
+// WhereLikePattern for a pattern.
+func (s *SelectStmt) WhereLike(column string, value string) *SelectStmt {
+	s.whereLike(column, ` LIKE `, EscapeLike(value))
+	return s
+}
+
+// WhereNotLike adds a WHERE column NOT LIKE value clause to the SELECT statement.
+// The value is escaped, see WhereLike.
+func (s *SelectStmt) WhereNotLike(column string, value string) *SelectStmt {
+	s.whereLike(column, ` NOT LIKE `, EscapeLike(value))
+	return s
+}
+
+// WhereILike adds a case-insensitive WHERE column ILIKE value clause to the SELECT
+// statement. Dialects without ILIKE compare LOWER(column) and LOWER(value).
+// The value is escaped, see WhereLike.
+func (s *SelectStmt) WhereILike(column string, value string) *SelectStmt {
+	s.whereILike(column, ILike, EscapeLike(value))
+	return s
+}
+
+// WhereLikePattern adds a WHERE column LIKE pattern clause to the SELECT statement.
+// The pattern is used as is, so % and _ in it are wildcards: build it with
+// Contains, HasPrefix, HasSuffix or EscapeLike to match user input literally.
+func (s *SelectStmt) WhereLikePattern(column string, pattern string) *SelectStmt {
+	s.whereLike(column, ` LIKE `, pattern)
+	return s
+}
+
+// WhereNotLikePattern adds a WHERE column NOT LIKE pattern clause to the SELECT
+// statement. The pattern is used as is, see WhereLikePattern.
+func (s *SelectStmt) WhereNotLikePattern(column string, pattern string) *SelectStmt {
+	s.whereLike(column, ` NOT LIKE `, pattern)
+	return s
+}
+
+// WhereILikePattern adds a case-insensitive WHERE column ILIKE pattern clause to
+// the SELECT statement. The pattern is used as is, see WhereLikePattern.
+func (s *SelectStmt) WhereILikePattern(column string, pattern string) *SelectStmt {
+	s.whereILike(column, ILike, pattern)
+	return s
+}
+
+// WhereRow adds a WHERE (columns) operator (values) row value comparison to the SELECT
+// statement, such as (created_at,id)>($1,$2). The operator must be =, <>, <, <=, > or >=.
+func (s *SelectStmt) WhereRow(columns []string, ex string, values []any) *SelectStmt {
+	s.whereRow(columns, ex, values)
+	return s
+}
+
 // WhereRaw adds a raw WHERE clause to the SELECT statement.
 func (s *SelectStmt) WhereRaw(raw string) *SelectStmt {
 	s.whereRaw(raw)
@@ -69,7 +158,7 @@ func (s *SelectStmt) WhereRaw(raw string) *SelectStmt {
 
 // OrderBy adds an ORDER BY clause to the SELECT statement.
 func (s *SelectStmt) OrderBy(columns ...string) *SelectStmt {
-	s.orderBy = " ORDER BY " + strings.Join(columns, ",")
+	s.orderBy = columns
 	return s
 }
 
@@ -87,19 +176,19 @@ func (s *SelectStmt) Offset(offset int) *SelectStmt {
 
 // Join adds a JOIN clause to the SELECT statement.
 func (s *SelectStmt) Join(table string, on1 string, on2 string) *SelectStmt {
-	s.joins = append(s.joins, "JOIN "+table+" ON "+on1+"="+on2)
+	s.joins = append(s.joins, join{table: table, on: []string{on1 + "=" + on2}})
 	return s
 }
 
 // GroupBy adds a GROUP BY clause to the SELECT statement.
 func (s *SelectStmt) GroupBy(columns ...string) *SelectStmt {
-	s.groupBy = " GROUP BY " + strings.Join(columns, ",")
+	s.groupBy = columns
 	return s
 }
 
 // Having adds a HAVING clause to the SELECT statement.
 func (s *SelectStmt) Having(conditions ...string) *SelectStmt {
-	s.having = " HAVING " + strings.Join(conditions, ",")
+	s.having = conditions
 	return s
 }
 
@@ -113,6 +202,7 @@ func (s *SelectStmt) ClearSelect() *SelectStmt {
 func (s *SelectStmt) ClearWhere() *SelectStmt {
 	s.where = nil
 	s.args = nil
+	s.invalid = nil
 	return s
 }
 
@@ -124,49 +214,126 @@ func (s *SelectStmt) ClearJoin() *SelectStmt {
 
 // ClearGroupBy clears the GROUP BY clause in the SELECT statement.
 func (s *SelectStmt) ClearGroupBy() *SelectStmt {
-	s.groupBy = ""
+	s.groupBy = nil
 	return s
 }
 
 // ClearHaving clears the HAVING clause in the SELECT statement.
 func (s *SelectStmt) ClearHaving() *SelectStmt {
-	s.having = ""
+	s.having = nil
 	return s
 }
 
 // ClearOrderBy clears the ORDER BY clause in the SELECT statement.
 func (s *SelectStmt) ClearOrderBy() *SelectStmt {
-	s.orderBy = ""
+	s.orderBy = nil
 	return s
 }
 
+// Clone returns a deep copy of the SELECT statement, so a base query can be
+// extended without affecting it or other statements cloned from it.
+func (s *SelectStmt) Clone() *SelectStmt {
+	c := *s
+	c.columns = slices.Clone(s.columns)
+	c.whereClause = s.whereClause.clone()
+	c.joins = cloneJoins(s.joins)
+	c.groupBy = slices.Clone(s.groupBy)
+	c.having = slices.Clone(s.having)
+	c.orderBy = slices.Clone(s.orderBy)
+	if s.sub != nil {
+		c.sub = s.sub.Clone()
+	}
+	return &c
+}
+
+// Count derives a statement that counts the rows matched by the SELECT statement.
+// It keeps the joins and the WHERE clause with its arguments and drops the selected
+// columns, ORDER BY, LIMIT and OFFSET. Statements with GROUP BY, HAVING or DISTINCT
+// are counted in a subquery, which is rendered when the count statement is.
+func (s *SelectStmt) Count() *SelectStmt {
+	count := &SelectStmt{
+		table:       s.table,
+		columns:     []string{"COUNT(*)"},
+		joins:       cloneJoins(s.joins),
+		whereClause: s.whereClause.clone(),
+	}
+
+	if s.groupBy != nil || s.having != nil || (len(s.columns) > 0 && strings.HasPrefix(strings.ToUpper(s.columns[0]), "DISTINCT ")) {
+		sub := s.Clone()
+		sub.orderBy = nil
+		sub.limit = 0
+		sub.offset = 0
+
+		count.sub = sub
+		count.joins = nil
+		count.where = nil
+	}
+	return count
+}
+
 // ToSql generates the SQL query string and the corresponding arguments for the SELECT statement.
 func (s *SelectStmt) ToSql() (string, []any) {
-	var query string
+	return render(s.renderDialect(), s.appendSql, s.args)
+}
+
+// AppendSql appends the SQL query of the SELECT statement to buf and returns the
+// extended buffer and the corresponding arguments.
+func (s *SelectStmt) AppendSql(buf []byte) ([]byte, []any) {
+	d := s.renderDialect()
+	return d.bindOrder(s.appendSql(buf, d), len(buf), s.args)
+}
 
+func (s *SelectStmt) appendSql(b []byte, d *Dialect) []byte {
+	b = append(b, "SELECT "...)
 	if s.columns == nil {
-		query = "SELECT * FROM " + s.table
+		b = append(b, '*')
 	} else {
-		query = "SELECT " + strings.Join(s.columns, `,`) + " FROM " + s.table
+		b = appendList(b, s.columns, ",")
 	}
-
-	if s.joins != nil {
-		query += " " + strings.Join(s.joins, " ")
+	b = append(b, " FROM "...)
+	if s.sub != nil {
+		b = append(b, '(')
+		b = s.sub.appendSql(b, d)
+		b = append(b, ") AS t"...)
+	} else {
+		b = append(b, s.table...)
 	}
+	b = appendJoins(b, s.joins)
+	b = appendWhere(b, s.where)
 
-	if s.where != nil {
-		query += " WHERE " + strings.Join(s.where, " AND ")
+	if s.groupBy != nil {
+		b = append(b, " GROUP BY "...)
+		b = appendList(b, s.groupBy, ",")
 	}
-
-	query += s.orderBy + s.groupBy + s.having
-
-	if s.limit > 0 {
-		query += " LIMIT " + strconv.Itoa(s.limit)
+	if s.having != nil {
+		b = append(b, " HAVING "...)
+		b = appendList(b, s.having, ",")
 	}
+	b = appendOrderLimit(b, s.orderBy, s.limit)
 
 	if s.offset > 0 {
-		query += " OFFSET " + strconv.Itoa(s.offset)
+		b = append(b, " OFFSET "...)
+		b = strconv.AppendInt(b, int64(s.offset), 10)
+	}
+	return b
+}
+
+// Build generates the SQL query string and the corresponding arguments for the SELECT statement.
+// It returns a *BuildError if the statement is invalid.
+func (s *SelectStmt) Build() (string, []any, error) {
+	if s.table == "" {
+		return "", nil, &BuildError{Statement: "SELECT", Err: ErrNoTable}
+	}
+	if err := s.check("SELECT"); err != nil {
+		return "", nil, err
+	}
+	if s.limit < 0 {
+		return "", nil, &BuildError{Statement: "SELECT", Err: ErrNegativeLimit}
+	}
+	if s.offset < 0 {
+		return "", nil, &BuildError{Statement: "SELECT", Err: ErrNegativeOffset}
 	}
 
-	return query, s.args
+	query, args := s.ToSql()
+	return query, args, nil
 }
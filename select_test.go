@@ -1,7 +1,10 @@
 package sqls
 
 import (
+	"database/sql"
+	"errors"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 )
@@ -140,3 +143,344 @@ func TestSelect(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectBuild(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	var tests = []struct {
+		name string
+		stmt *SelectStmt
+		err  error
+	}{
+		{"no table", From(""), ErrNoTable},
+		{"negative limit", From("users").Limit(-1), ErrNegativeLimit},
+		{"negative offset", From("users").Offset(-1), ErrNegativeOffset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.stmt.Build()
+
+			var buildErr *BuildError
+			if !errors.Is(err, tt.err) || !errors.As(err, &buildErr) || buildErr.Statement != "SELECT" {
+				t.Errorf("invalid error: %v", err)
+			}
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		sql, args, err := From("users").Where("id", 1).Build()
+
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != `SELECT * FROM users WHERE id=$1` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{1}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+}
+
+func TestSelectWhereIn(t *testing.T) {
+	t.Run("empty in", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args, err := From("users").
+			Where("active", true).
+			WhereIn("id", []any{}).
+			WhereNotIn("role", nil).
+			Build()
+
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		if sql != `SELECT * FROM users WHERE active=$1 AND 1=0` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{true}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+
+	t.Run("not in", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args := From("users").
+			WhereNotIn("role", []any{"admin", "editor"}).
+			ToSql()
+
+		if sql != `SELECT * FROM users WHERE role NOT IN ($1,$2)` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{"admin", "editor"}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+
+	t.Run("any postgres", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		ids := []int64{1, 2, 3}
+		sql, args := From("users").
+			Where("active", true).
+			WhereAny("id", ids).
+			ToSql()
+
+		if sql != `SELECT * FROM users WHERE active=$1 AND id=ANY($2)` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{true, ids}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+
+	t.Run("any default", func(t *testing.T) {
+		SetDialect(DefaultDialect)
+
+		sql, args := From("users").
+			WhereAny("id", []int64{1, 2, 3}).
+			ToSql()
+
+		if sql != `SELECT * FROM users WHERE id IN (@1,@2,@3)` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{int64(1), int64(2), int64(3)}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+}
+
+func TestSelectPredicates(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{"postgres", PostgreSQL, `SELECT * FROM users WHERE role<>$1 AND age BETWEEN $2 AND $3 AND score NOT BETWEEN $4 AND $5 AND name LIKE $6 ESCAPE '\' AND email NOT LIKE $7 ESCAPE '\' AND city ILIKE $8 ESCAPE '\'`},
+		{"mysql", MySQL, `SELECT * FROM users WHERE role<>? AND age BETWEEN ? AND ? AND score NOT BETWEEN ? AND ? AND name LIKE ? ESCAPE '\\' AND email NOT LIKE ? ESCAPE '\\' AND LOWER(city) LIKE LOWER(?) ESCAPE '\\'`},
+		{"sqlite", SQLite, `SELECT * FROM users WHERE role<>@1 AND age BETWEEN @2 AND @3 AND score NOT BETWEEN @4 AND @5 AND name LIKE @6 ESCAPE '\' AND email NOT LIKE @7 ESCAPE '\' AND LOWER(city) LIKE LOWER(@8) ESCAPE '\'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args := From("users").
+				WhereNotEquals("role", "admin").
+				WhereBetween("age", 18, 65).
+				WhereNotBetween("score", 0, 10).
+				WhereLikePattern("name", Contains("50%_off")).
+				WhereNotLikePattern("email", HasSuffix("@example.com")).
+				WhereILikePattern("city", HasPrefix("san")).
+				ToSql()
+
+			if sql != tt.want {
+				t.Error("Invalid sql: " + sql)
+			}
+			if !reflect.DeepEqual(args, []any{"admin", 18, 65, 0, 10, `%50\%\_off%`, `%@example.com`, `san%`}) {
+				t.Errorf("Invalid args: %v", args)
+			}
+		})
+	}
+
+	t.Run("escaped", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args := From("users").
+			WhereLike("name", "50%_off").
+			WhereNotLike("email", `a\b`).
+			WhereILike("city", "San_").
+			ToSql()
+
+		if sql != `SELECT * FROM users WHERE name LIKE $1 ESCAPE '\' AND email NOT LIKE $2 ESCAPE '\' AND city ILIKE $3 ESCAPE '\'` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{`50\%\_off`, `a\\b`, `San\_`}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+}
+
+func TestSelectWhereNil(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	var deletedAt *time.Time
+	query, args := From("users").
+		Where("deleted_at", nil).
+		Where("banned_at", deletedAt).
+		Where("nickname", sql.NullString{}).
+		WhereNotEquals("email", nil).
+		Where("name", sql.NullString{String: "John", Valid: true}).
+		ToSql()
+
+	if query != `SELECT * FROM users WHERE deleted_at IS NULL AND banned_at IS NULL AND nickname IS NULL AND email IS NOT NULL AND name=$1` {
+		t.Error("Invalid sql: " + query)
+	}
+	if !reflect.DeepEqual(args, []any{sql.NullString{String: "John", Valid: true}}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+}
+
+func TestSelectCount(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	t.Run("count", func(t *testing.T) {
+		query := From("users").
+			Select("id", "name").
+			Join("roles", "users.id", "roles.user_id").
+			Where("active", true).
+			WhereIn("roles.role", []any{"admin", "editor"}).
+			OrderBy("id DESC").
+			Limit(20).Offset(100)
+
+		sql, args := query.Count().ToSql()
+		if sql != `SELECT COUNT(*) FROM users JOIN roles ON users.id=roles.user_id WHERE active=$1 AND roles.role IN ($2,$3)` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{true, "admin", "editor"}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+
+		sql, _ = query.ToSql()
+		if sql != `SELECT id,name FROM users JOIN roles ON users.id=roles.user_id WHERE active=$1 AND roles.role IN ($2,$3) ORDER BY id DESC LIMIT 20 OFFSET 100` {
+			t.Error("Invalid sql: " + sql)
+		}
+	})
+
+	t.Run("count group by", func(t *testing.T) {
+		sql, args := From("users").
+			Select("state", "COUNT(state) as count").
+			Where("active", true).
+			GroupBy("state").
+			Having("COUNT(state) > 5").
+			Limit(10).
+			Count().
+			ToSql()
+
+		if sql != `SELECT COUNT(*) FROM (SELECT state,COUNT(state) as count FROM users WHERE active=$1 GROUP BY state HAVING COUNT(state) > 5) AS t` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{true}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+
+	t.Run("count having", func(t *testing.T) {
+		sql, args := From("orders").
+			Select("SUM(total)").
+			Where("paid", true).
+			Having("SUM(total) > $2").
+			Count().
+			ToSql()
+
+		if sql != `SELECT COUNT(*) FROM (SELECT SUM(total) FROM orders WHERE paid=$1 HAVING SUM(total) > $2) AS t` {
+			t.Error("Invalid sql: " + sql)
+		}
+		if !reflect.DeepEqual(args, []any{true}) {
+			t.Errorf("Invalid args: %v", args)
+		}
+	})
+
+	t.Run("count rendered lazily", func(t *testing.T) {
+		query := From("users").Select("DISTINCT state").Where("active", true)
+		count := query.Count()
+		query.Where("tenant", 7)
+
+		sql, _ := count.ToSql()
+		if sql != `SELECT COUNT(*) FROM (SELECT DISTINCT state FROM users WHERE active=$1) AS t` {
+			t.Error("Invalid sql: " + sql)
+		}
+	})
+
+	t.Run("count distinct", func(t *testing.T) {
+		sql, _ := From("users").Select("DISTINCT state").Count().ToSql()
+
+		if sql != `SELECT COUNT(*) FROM (SELECT DISTINCT state FROM users) AS t` {
+			t.Error("Invalid sql: " + sql)
+		}
+	})
+}
+
+func TestSelectClone(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	base := From("users").Select("id").Where("tenant", 1)
+	// leave spare capacity so appends to a shared slice would alias
+	base.where = slices.Grow(base.where, 10)
+	base.args = slices.Grow(base.args, 10)
+
+	a := base.Clone().Where("active", true)
+	b := base.Clone().Where("role", "admin").Select("id", "name")
+
+	sql, args := a.ToSql()
+	if sql != `SELECT id FROM users WHERE tenant=$1 AND active=$2` {
+		t.Error("Invalid sql: " + sql)
+	}
+	if !reflect.DeepEqual(args, []any{1, true}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+
+	sql, args = b.ToSql()
+	if sql != `SELECT id,name FROM users WHERE tenant=$1 AND role=$2` {
+		t.Error("Invalid sql: " + sql)
+	}
+	if !reflect.DeepEqual(args, []any{1, "admin"}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+
+	sql, args = base.ToSql()
+	if sql != `SELECT id FROM users WHERE tenant=$1` {
+		t.Error("Invalid sql: " + sql)
+	}
+	if !reflect.DeepEqual(args, []any{1}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+}
+
+func TestSelectAppendSql(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	s := From("users").Select("id", "name").Where("id", 1).GroupBy("id").OrderBy("name")
+	buf, args := s.AppendSql([]byte("EXPLAIN "))
+
+	if string(buf) != `EXPLAIN SELECT id,name FROM users WHERE id=$1 GROUP BY id ORDER BY name` {
+		t.Error("Invalid sql: " + string(buf))
+	}
+	if !reflect.DeepEqual(args, []any{1}) {
+		t.Errorf("Invalid args: %v", args)
+	}
+
+	if sql, _ := s.ToSql(); sql != string(buf[len("EXPLAIN "):]) {
+		t.Error("Invalid sql: " + sql)
+	}
+}
+
+// TestSelectClauseOrder checks that GROUP BY and HAVING are rendered before
+// ORDER BY, LIMIT and OFFSET whatever the order the methods are called in.
+func TestSelectClauseOrder(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	var tests = []struct {
+		name string
+		stmt *SelectStmt
+		want string
+	}{
+		{"group by", From("users").Select("state").OrderBy("state").GroupBy("state"),
+			`SELECT state FROM users GROUP BY state ORDER BY state`},
+		{"having", From("users").Select("state").Limit(5).Having("COUNT(*) > 1").GroupBy("state").OrderBy("state"),
+			`SELECT state FROM users GROUP BY state HAVING COUNT(*) > 1 ORDER BY state LIMIT 5`},
+		{"offset", From("users").Select("state").Offset(10).OrderBy("state").Where("active", true).GroupBy("state"),
+			`SELECT state FROM users WHERE active=$1 GROUP BY state ORDER BY state OFFSET 10`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _ := tt.stmt.ToSql()
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+		})
+	}
+}
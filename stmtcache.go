@@ -0,0 +1,229 @@
+package sqls
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// Preparer is a database that prepares statements. It is implemented by
+// *sql.DB and *sql.Conn.
+type Preparer interface {
+	Executor
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// StmtCache is an Executor that prepares each distinct SQL string once on a
+// database and reuses the prepared statement. It keeps the most recently used
+// statements and closes the others. Use one cache per *sql.DB or *sql.Conn.
+// A StmtCache is safe for concurrent use.
+type StmtCache struct {
+	db    Preparer
+	size  int
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+	stats StmtCacheStats
+}
+
+// StmtCacheStats counts the statements found in the cache, prepared and
+// evicted by a StmtCache.
+type StmtCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+// cachedStmt is a prepared statement that is closed when it has been evicted
+// and is no longer in use.
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// NewStmtCache creates a cache of up to size prepared statements on db.
+func NewStmtCache(db Preparer, size int) *StmtCache {
+	return &StmtCache{
+		db:    db,
+		size:  max(size, 1),
+		lru:   list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+// Stats returns the statistics of the cache.
+func (c *StmtCache) Stats() StmtCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Len = c.lru.Len()
+	return stats
+}
+
+// Remove closes the prepared statement of the query, for example after a
+// schema change made it invalid.
+func (c *StmtCache) Remove(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[query]; ok {
+		c.evict(e)
+	}
+}
+
+// Invalidate closes all prepared statements, for example when the connection
+// of a *sql.Conn was reset.
+func (c *StmtCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.lru.Len() > 0 {
+		c.evict(c.lru.Back())
+	}
+}
+
+// Close closes all prepared statements.
+func (c *StmtCache) Close() error {
+	c.Invalidate()
+	return nil
+}
+
+// ExecContext executes the prepared statement of the query.
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	cs, err := c.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(cs)
+	return cs.stmt.ExecContext(ctx, args...)
+}
+
+// QueryContext runs the prepared statement of the query.
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	cs, err := c.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(cs)
+	return cs.stmt.QueryContext(ctx, args...)
+}
+
+// QueryRowContext runs the prepared statement of the query, expecting at most
+// one row. If the query cannot be prepared it is run without preparing it, so
+// the error is returned by the Scan of the row.
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	cs, err := c.get(ctx, query)
+	if err != nil {
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+	defer c.release(cs)
+	return cs.stmt.QueryRowContext(ctx, args...)
+}
+
+// Tx returns an Executor that runs the cached statements in the transaction tx,
+// which must have been started on the database of the cache.
+func (c *StmtCache) Tx(tx *sql.Tx) Executor {
+	return txStmtCache{cache: c, tx: tx}
+}
+
+func (c *StmtCache) get(ctx context.Context, query string) (*cachedStmt, error) {
+	c.mu.Lock()
+	if cs := c.lookup(query); cs != nil {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return cs, nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the query was prepared by another goroutine in the meantime
+	if cs := c.lookup(query); cs != nil {
+		stmt.Close()
+		return cs, nil
+	}
+
+	cs := &cachedStmt{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.lru.PushFront(cs)
+	for c.lru.Len() > c.size {
+		c.evict(c.lru.Back())
+		c.stats.Evictions++
+	}
+	return cs, nil
+}
+
+// lookup returns the cached statement of the query in use, c.mu must be held.
+func (c *StmtCache) lookup(query string) *cachedStmt {
+	e, ok := c.items[query]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(e)
+	cs := e.Value.(*cachedStmt)
+	cs.refs++
+	return cs
+}
+
+// evict removes the element from the cache, c.mu must be held.
+func (c *StmtCache) evict(e *list.Element) {
+	cs := c.lru.Remove(e).(*cachedStmt)
+	delete(c.items, cs.query)
+	cs.evicted = true
+	if cs.refs == 0 {
+		cs.stmt.Close()
+	}
+}
+
+func (c *StmtCache) release(cs *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cs.refs--
+	if cs.evicted && cs.refs == 0 {
+		cs.stmt.Close()
+	}
+}
+
+type txStmtCache struct {
+	cache *StmtCache
+	tx    *sql.Tx
+}
+
+func (t txStmtCache) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	cs, err := t.cache.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer t.cache.release(cs)
+	return t.tx.StmtContext(ctx, cs.stmt).ExecContext(ctx, args...)
+}
+
+func (t txStmtCache) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	cs, err := t.cache.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer t.cache.release(cs)
+	return t.tx.StmtContext(ctx, cs.stmt).QueryContext(ctx, args...)
+}
+
+func (t txStmtCache) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	cs, err := t.cache.get(ctx, query)
+	if err != nil {
+		return t.tx.QueryRowContext(ctx, query, args...)
+	}
+	defer t.cache.release(cs)
+	return t.tx.StmtContext(ctx, cs.stmt).QueryRowContext(ctx, args...)
+}
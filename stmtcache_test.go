@@ -0,0 +1,116 @@
+package sqls
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestStmtCache(t *testing.T) {
+	SetDialect(PostgreSQL)
+	ctx := context.Background()
+
+	t.Run("reuse", func(t *testing.T) {
+		db, tdb := openTestDB(t, []string{"id"}, []driver.Value{int64(1)})
+		cache := NewStmtCache(db, 10)
+
+		for i := range 3 {
+			if _, err := Update("users").Set("active", false).Where("id", i).Exec(ctx, cache); err != nil {
+				t.Errorf("invalid error: %v", err)
+			}
+		}
+		if _, err := All[int](ctx, cache, From("users").Select("id")); err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+
+		if tdb.prepared != 2 {
+			t.Errorf("invalid prepared: %d", tdb.prepared)
+		}
+		if stats := cache.Stats(); stats != (StmtCacheStats{Hits: 2, Misses: 2, Len: 2}) {
+			t.Errorf("invalid stats: %+v", stats)
+		}
+		if !reflect.DeepEqual(tdb.args[2], []any{false, int64(2)}) {
+			t.Errorf("invalid args: '%v'", tdb.args[2])
+		}
+	})
+
+	t.Run("evict", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+		cache := NewStmtCache(db, 2)
+
+		for _, table := range []string{"a", "b", "c", "a"} {
+			if _, err := Delete(table).Where("id", 1).Exec(ctx, cache); err != nil {
+				t.Errorf("invalid error: %v", err)
+			}
+		}
+
+		if stats := cache.Stats(); stats != (StmtCacheStats{Misses: 4, Evictions: 2, Len: 2}) {
+			t.Errorf("invalid stats: %+v", stats)
+		}
+		if tdb.prepared != 4 || tdb.closed != 2 {
+			t.Errorf("invalid prepared: %d, closed: %d", tdb.prepared, tdb.closed)
+		}
+
+		cache.Remove("DELETE FROM a WHERE id=$1")
+		if stats := cache.Stats(); stats.Len != 1 || tdb.closed != 3 {
+			t.Errorf("invalid stats: %+v, closed: %d", stats, tdb.closed)
+		}
+		cache.Invalidate()
+		if stats := cache.Stats(); stats.Len != 0 || tdb.closed != 4 {
+			t.Errorf("invalid stats: %+v, closed: %d", stats, tdb.closed)
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+		cache := NewStmtCache(db, 10)
+
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range 2 {
+			if _, err := Delete("sessions").Where("user_id", 7).Exec(ctx, cache.Tx(tx)); err != nil {
+				t.Errorf("invalid error: %v", err)
+			}
+		}
+		tx.Commit()
+
+		want := []string{"BEGIN", "DELETE FROM sessions WHERE user_id=$1", "DELETE FROM sessions WHERE user_id=$1", "COMMIT"}
+		if !reflect.DeepEqual(tdb.queries, want) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+		if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+			t.Errorf("invalid stats: %+v", stats)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		db, _ := openTestDB(t, nil)
+		cache := NewStmtCache(db, 2)
+		defer cache.Close()
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 50 {
+					table := "t" + strconv.Itoa((i+j)%3)
+					if _, err := Delete(table).Where("id", j).Exec(ctx, cache); err != nil {
+						t.Errorf("invalid error: %v", err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if stats := cache.Stats(); stats.Hits+stats.Misses != 400 || stats.Len > 2 {
+			t.Errorf("invalid stats: %+v", stats)
+		}
+	})
+}
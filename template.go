@@ -0,0 +1,201 @@
+package sqls
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Statement is a statement that renders to SQL and its arguments.
+type Statement interface {
+	ToSql() (string, []any)
+	Build() (string, []any, error)
+}
+
+// Slot is a named argument of a statement that is bound when the compiled
+// Template is executed. Create slots with Arg and ArgList.
+type Slot struct {
+	name string
+	list bool
+}
+
+// Arg returns a slot for a single value named name, for example
+// From("users").Where("id", sqls.Arg("id")). A slot is always rendered as a
+// placeholder, so binding nil to Where("id", sqls.Arg("id")) does not render IS NULL.
+func Arg(name string) Slot {
+	return Slot{name: name}
+}
+
+// ArgList returns the values for WhereIn or WhereNotIn with a slot named name
+// that expands to one placeholder per element of the slice bound to it.
+func ArgList(name string) []any {
+	return []any{Slot{name: name, list: true}}
+}
+
+// Template is a statement compiled once and executed with different values
+// for its slots. A Template is safe for concurrent use.
+type Template struct {
+	sql     string
+	parts   []string // SQL between the placeholders, one more than refs
+	refs    []int    // argument index of each placeholder
+	args    []any
+	slots   []int // indexes of the slot arguments
+	lists   bool
+	dialect *Dialect
+}
+
+// Compile renders the statement with the dialect it was built with into a template.
+// Arguments created with Arg and ArgList become slots; all other arguments
+// are kept as they are.
+func Compile(stmt Statement) (*Template, error) {
+	sql, args, err := stmt.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Template{sql: sql, args: args, dialect: dialectOf(stmt)}
+	kinds := map[string]bool{}
+	for i, arg := range args {
+		slot, ok := arg.(Slot)
+		if !ok {
+			continue
+		}
+		if list, seen := kinds[slot.name]; seen && list != slot.list {
+			return nil, fmt.Errorf("%w: %q is used as a value and a list", ErrInvalidArg, slot.name)
+		}
+		kinds[slot.name] = slot.list
+		t.slots = append(t.slots, i)
+		t.lists = t.lists || slot.list
+	}
+
+	if t.lists {
+		t.split()
+	}
+	return t, nil
+}
+
+// MustCompile is like Compile but panics if the statement is invalid. It is
+// meant for templates compiled when a package is initialized.
+func MustCompile(stmt Statement) *Template {
+	t, err := Compile(stmt)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// split cuts the SQL at its placeholders, so list slots can be expanded and
+// the placeholders after them renumbered.
+func (t *Template) split() {
+	t.parts, t.refs = t.dialect.splitParams(t.sql, len(t.args))
+}
+
+// splitParams cuts the SQL at the placeholders of the n arguments, skipping
+// quoted strings. It returns the SQL between the placeholders, one more than
+// the placeholders, and the argument index of each placeholder. The
+// placeholders of a positional dialect refer to the arguments in order.
+func (d *Dialect) splitParams(sql string, n int) ([]string, []int) {
+	var parts []string
+	var refs []int
+	ph := d.placeholder
+	start := 0
+	quoted := false
+
+	for i := 0; i < len(sql); i++ {
+		if sql[i] == '\'' {
+			quoted = !quoted
+		}
+		if quoted || !hasPrefixAt(sql, i, ph) {
+			continue
+		}
+		j := i + len(ph)
+		for j < len(sql) && sql[j] >= '0' && sql[j] <= '9' {
+			j++
+		}
+		x, err := strconv.Atoi(sql[i+len(ph) : j])
+		if d.positional && j == i+len(ph) {
+			x, err = len(refs)+1, nil
+		}
+		if err != nil || x < 1 || x > n {
+			continue
+		}
+		parts = append(parts, sql[start:i])
+		refs = append(refs, x-1)
+		start = j
+		i = j - 1
+	}
+	return append(parts, sql[start:]), refs
+}
+
+func hasPrefixAt[S string | []byte](s S, i int, prefix string) bool {
+	return len(s)-i >= len(prefix) && string(s[i:i+len(prefix)]) == prefix
+}
+
+// Bind returns the SQL and the arguments of the template with its slots set
+// to the values. A list slot takes a slice and is expanded to one placeholder
+// per element. It returns an error wrapping ErrMissingArg if a slot has no
+// value and ErrInvalidArg if a list slot gets an empty list.
+func (t *Template) Bind(values map[string]any) (string, []any, error) {
+	if !t.lists {
+		args := make([]any, len(t.args))
+		copy(args, t.args)
+		for _, i := range t.slots {
+			name := t.args[i].(Slot).name
+			v, ok := values[name]
+			if !ok {
+				return "", nil, fmt.Errorf("%w: %q", ErrMissingArg, name)
+			}
+			args[i] = v
+		}
+		return t.sql, args, nil
+	}
+
+	// number each argument after the expanded lists before it
+	lists := make([][]any, len(t.args))
+	first := make([]int, len(t.args))
+	n := len(t.args)
+	for i, arg := range t.args {
+		if slot, ok := arg.(Slot); ok && slot.list {
+			v, ok := values[slot.name]
+			if !ok {
+				return "", nil, fmt.Errorf("%w: %q", ErrMissingArg, slot.name)
+			}
+			if lists[i] = expand(v); len(lists[i]) == 0 {
+				return "", nil, fmt.Errorf("%w: empty list for %q", ErrInvalidArg, slot.name)
+			}
+			n += len(lists[i]) - 1
+		}
+	}
+
+	args := make([]any, 0, n)
+	for i, arg := range t.args {
+		first[i] = len(args) + 1
+		slot, ok := arg.(Slot)
+		switch {
+		case lists[i] != nil:
+			args = append(args, lists[i]...)
+		case ok:
+			v, ok := values[slot.name]
+			if !ok {
+				return "", nil, fmt.Errorf("%w: %q", ErrMissingArg, slot.name)
+			}
+			args = append(args, v)
+		default:
+			args = append(args, arg)
+		}
+	}
+
+	bp := getBuf()
+	b := *bp
+	for i, ref := range t.refs {
+		b = append(b, t.parts[i]...)
+		count := 1
+		if lists[ref] != nil {
+			count = len(lists[ref])
+		}
+		b = t.dialect.appendParams(b, first[ref], count)
+	}
+	b = append(b, t.parts[len(t.parts)-1]...)
+
+	b, args = t.dialect.bindOrder(b, 0, args)
+	return bufString(bp, b), args, nil
+}
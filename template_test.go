@@ -0,0 +1,162 @@
+package sqls
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		values  map[string]any
+		want    string
+		args    []any
+	}{
+		{"postgres", PostgreSQL, map[string]any{"tenant": 7, "roles": []string{"admin", "staff"}, "name": "J%"},
+			`SELECT id FROM users WHERE tenant=$1 AND role IN ($2,$3) AND active=$4 AND name LIKE $5 ESCAPE '\' AND note<>'$1 @1' LIMIT 10`,
+			[]any{7, "admin", "staff", true, "J%"}},
+		{"postgres one role", PostgreSQL, map[string]any{"tenant": 7, "roles": []any{"admin"}, "name": "J%"},
+			`SELECT id FROM users WHERE tenant=$1 AND role IN ($2) AND active=$3 AND name LIKE $4 ESCAPE '\' AND note<>'$1 @1' LIMIT 10`,
+			[]any{7, "admin", true, "J%"}},
+		{"mysql", MySQL, map[string]any{"tenant": 7, "roles": []string{"admin", "staff", "guest"}, "name": "J%"},
+			`SELECT id FROM users WHERE tenant=? AND role IN (?,?,?) AND active=? AND name LIKE ? ESCAPE '\\' AND note<>'$1 @1' LIMIT 10`,
+			[]any{7, "admin", "staff", "guest", true, "J%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			tmpl, err := Compile(From("users").
+				Select("id").
+				Where("tenant", Arg("tenant")).
+				WhereIn("role", ArgList("roles")).
+				Where("active", true).
+				WhereExp("name", "LIKE", Arg("name")).
+				WhereRaw("note<>'$1 @1'").
+				Limit(10))
+			if err != nil {
+				t.Fatalf("invalid error: %v", err)
+			}
+
+			sql, args, err := tmpl.Bind(tt.values)
+			if err != nil {
+				t.Errorf("invalid error: %v", err)
+			}
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("invalid args: '%v'", args)
+			}
+		})
+	}
+}
+
+func TestTemplateValues(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	tmpl, err := Compile(Update("users").Set("name", Arg("name")).Where("id", Arg("id")))
+	if err != nil {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	sql1, args, _ := tmpl.Bind(map[string]any{"id": 1, "name": "John"})
+	if sql1 != "UPDATE users SET name=$1 WHERE id=$2" {
+		t.Errorf("invalid sql: '%s'", sql1)
+	}
+	if !reflect.DeepEqual(args, []any{"John", 1}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+
+	sql2, args, _ := tmpl.Bind(map[string]any{"id": 2, "name": "Jane"})
+	if sql2 != sql1 {
+		t.Errorf("invalid sql: '%s'", sql2)
+	}
+	if !reflect.DeepEqual(args, []any{"Jane", 2}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	tmpl, _ := Compile(From("users").Where("tenant", Arg("tenant")).WhereIn("id", ArgList("ids")))
+
+	t.Run("missing value", func(t *testing.T) {
+		_, _, err := tmpl.Bind(map[string]any{"ids": []int{1}})
+		if !errors.Is(err, ErrMissingArg) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("missing list", func(t *testing.T) {
+		_, _, err := tmpl.Bind(map[string]any{"tenant": 1})
+		if !errors.Is(err, ErrMissingArg) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		_, _, err := tmpl.Bind(map[string]any{"tenant": 1, "ids": []int{}})
+		if !errors.Is(err, ErrInvalidArg) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("value and list", func(t *testing.T) {
+		_, err := Compile(From("users").Where("id", Arg("id")).WhereIn("id", ArgList("id")))
+		if !errors.Is(err, ErrInvalidArg) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("invalid statement", func(t *testing.T) {
+		_, err := Compile(From("").Where("id", Arg("id")))
+		if !errors.Is(err, ErrNoTable) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+
+	t.Run("must compile", func(t *testing.T) {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrNoTable) {
+				t.Errorf("invalid panic: %v", err)
+			}
+		}()
+		MustCompile(From(""))
+	})
+}
+
+func BenchmarkTemplate(b *testing.B) {
+	SetDialect(PostgreSQL)
+
+	tmpl, _ := Compile(From("users").
+		Select("id", "name").
+		Where("tenant", Arg("tenant")).
+		Where("active", true).
+		OrderBy("name").
+		Limit(20))
+	list, _ := Compile(From("users").
+		Select("id", "name").
+		Where("tenant", Arg("tenant")).
+		WhereIn("id", ArgList("ids")).
+		OrderBy("name"))
+
+	b.Run("Bind", func(b *testing.B) {
+		b.ReportAllocs()
+		values := map[string]any{"tenant": 7}
+		for range b.N {
+			tmpl.Bind(values)
+		}
+	})
+	b.Run("BindList", func(b *testing.B) {
+		b.ReportAllocs()
+		values := map[string]any{"tenant": 7, "ids": []any{1, 2, 3, 4, 5}}
+		for range b.N {
+			list.Bind(values)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package sqls
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Tx is a transaction started by InTx. It is an Executor, so statements run
+// in the transaction with Exec, Query and QueryRow. Pass it to InTx to run a
+// nested transaction in a savepoint.
+type Tx struct {
+	*sql.Tx
+	depth int
+}
+
+// TxOptions are the options of InTx. The zero value runs a transaction with
+// the default isolation level and retries it up to 3 times.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+
+	// MaxAttempts is the number of times the transaction is run, 0 means 3.
+	MaxAttempts int
+	// Retryable reports whether the transaction can be retried after the
+	// error, nil means IsRetryable.
+	Retryable func(err error) bool
+	// Backoff returns the time to wait before the attempt after attempt,
+	// nil means an exponential backoff with jitter from 10ms to 1s.
+	Backoff func(attempt int) time.Duration
+}
+
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// InTx runs fn in a transaction on db, which is a *sql.DB or *sql.Conn. The
+// transaction is committed if fn returns nil and rolled back if it returns an
+// error or panics. It is run again if it fails with an error that can be
+// retried, such as a deadlock or a serialization failure, so fn must not have
+// side effects outside the transaction.
+//
+// If db is a *Tx or *sql.Tx, fn runs in a savepoint of that transaction that
+// is rolled back if fn fails. Nested transactions are not retried on their
+// own, the error is returned to the outer transaction.
+func InTx(ctx context.Context, db Executor, opts *TxOptions, fn func(tx *Tx) error) error {
+	var b txBeginner
+	switch x := db.(type) {
+	case *Tx:
+		return x.savepoint(ctx, fn)
+	case *sql.Tx:
+		return (&Tx{Tx: x}).savepoint(ctx, fn)
+	case txBeginner:
+		b = x
+	default:
+		return fmt.Errorf("%w: %T cannot begin a transaction", ErrNotSupported, db)
+	}
+
+	var o TxOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxAttempts < 1 {
+		o.MaxAttempts = 3
+	}
+	if o.Retryable == nil {
+		o.Retryable = IsRetryable
+	}
+	if o.Backoff == nil {
+		o.Backoff = backoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := runTx(ctx, b, &sql.TxOptions{Isolation: o.Isolation, ReadOnly: o.ReadOnly}, fn)
+		if err == nil || attempt >= o.MaxAttempts || !o.Retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(o.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func runTx(ctx context.Context, b txBeginner, opts *sql.TxOptions, fn func(tx *Tx) error) error {
+	tx, err := b.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&Tx{Tx: tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint runs fn in a savepoint of the transaction.
+func (t *Tx) savepoint(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	nested := &Tx{Tx: t.Tx, depth: t.depth + 1}
+	name := "sqls_" + strconv.Itoa(nested.depth)
+
+	save, rollback, release := "SAVEPOINT "+name, "ROLLBACK TO SAVEPOINT "+name, "RELEASE SAVEPOINT "+name
+	if curDialect.Load().kind == kindSQLServer {
+		save, rollback, release = "SAVE TRANSACTION "+name, "ROLLBACK TRANSACTION "+name, ""
+	}
+
+	if _, err := t.ExecContext(ctx, save); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			t.ExecContext(ctx, rollback)
+			panic(p)
+		}
+	}()
+
+	if err := fn(nested); err != nil {
+		if _, rerr := t.ExecContext(ctx, rollback); rerr != nil {
+			return errors.Join(err, rerr)
+		}
+		return err
+	}
+	if release != "" {
+		_, err = t.ExecContext(ctx, release)
+	}
+	return err
+}
+
+// IsRetryable reports whether a transaction that failed with err can be run
+// again for the current dialect: serialization failures and deadlocks
+// (SQLSTATE 40001 and 40P01) for PostgreSQL, deadlocks (1213) for MySQL and
+// deadlocks (1205) for SQL Server. The default dialect accepts all of them.
+// Errors are recognized by a SQLState() string method, a SQLErrorNumber()
+// int32 method or an integer Number field, as in the common drivers, anywhere
+// in the tree of err including errors joined with errors.Join.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	state, number := errorCode(err)
+
+	switch curDialect.Load().kind {
+	case kindPostgreSQL:
+		return state == "40001" || state == "40P01"
+	case kindMySQL:
+		return number == 1213 || state == "40001"
+	case kindSQLServer:
+		return number == 1205
+	case kindSQLite:
+		return false
+	}
+	return state == "40001" || state == "40P01" || number == 1213 || number == 1205
+}
+
+// errorCode returns the SQLSTATE and the error number of the first driver
+// error in the tree of err, including the errors joined by errors.Join.
+func errorCode(err error) (string, int64) {
+	for err != nil {
+		if state, number, ok := driverCode(err); ok {
+			return state, number
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				if state, number := errorCode(e); state != "" || number != 0 {
+					return state, number
+				}
+			}
+			return "", 0
+		default:
+			return "", 0
+		}
+	}
+	return "", 0
+}
+
+// driverCode returns the SQLSTATE or the error number of err if it is a driver error.
+func driverCode(err error) (string, int64, bool) {
+	if e, ok := err.(interface{ SQLState() string }); ok {
+		return e.SQLState(), 0, true
+	}
+	if e, ok := err.(interface{ SQLErrorNumber() int32 }); ok {
+		return "", int64(e.SQLErrorNumber()), true
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", 0, false
+	}
+	sf, ok := v.Type().FieldByName("Number")
+	if !ok {
+		return "", 0, false
+	}
+	f, ferr := v.FieldByIndexErr(sf.Index)
+	if ferr != nil {
+		return "", 0, false
+	}
+	switch f.Kind() {
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "", f.Int(), true
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "", int64(f.Uint()), true
+	}
+	return "", 0, false
+}
+
+// backoff waits 10ms after the first attempt and twice as long after every
+// following attempt up to 1s, with a random jitter of up to 50%.
+func backoff(attempt int) time.Duration {
+	d := min(10*time.Millisecond<<min(attempt-1, 10), time.Second)
+	return d/2 + rand.N(d/2+1)
+}
@@ -0,0 +1,253 @@
+package sqls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPgError struct{ state string }
+
+func (e *testPgError) Error() string    { return "pg error " + e.state }
+func (e *testPgError) SQLState() string { return e.state }
+
+type testMySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *testMySQLError) Error() string { return e.Message }
+
+type testMSSQLError struct{ number int32 }
+
+func (e testMSSQLError) Error() string         { return "mssql error" }
+func (e testMSSQLError) SQLErrorNumber() int32 { return e.number }
+
+func TestIsRetryable(t *testing.T) {
+	defer SetDialect(DefaultDialect)
+
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		err     error
+		want    bool
+	}{
+		{"postgres serialization", PostgreSQL, &testPgError{"40001"}, true},
+		{"postgres deadlock", PostgreSQL, fmt.Errorf("update: %w", &testPgError{"40P01"}), true},
+		{"postgres joined deadlock", PostgreSQL, errors.Join(errors.New("rollback failed"), fmt.Errorf("update: %w", &testPgError{"40P01"})), true},
+		{"postgres unique", PostgreSQL, &testPgError{"23505"}, false},
+		{"mysql deadlock", MySQL, &testMySQLError{Number: 1213}, true},
+		{"mysql duplicate", MySQL, &testMySQLError{Number: 1062}, false},
+		{"sql server deadlock", SQLServer, testMSSQLError{1205}, true},
+		{"sql server mysql code", SQLServer, &testMySQLError{Number: 1213}, false},
+		{"default", DefaultDialect, testMSSQLError{1205}, true},
+		{"other error", DefaultDialect, errors.New("boom"), false},
+		{"nil", DefaultDialect, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInTx(t *testing.T) {
+	SetDialect(PostgreSQL)
+	ctx := context.Background()
+	noWait := &TxOptions{Backoff: func(int) time.Duration { return 0 }}
+
+	t.Run("commit", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		err := InTx(ctx, db, nil, func(tx *Tx) error {
+			_, err := Update("users").Set("active", false).Where("id", 7).Exec(ctx, tx)
+			return err
+		})
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		want := []string{"BEGIN", "UPDATE users SET active=$1 WHERE id=$2", "COMMIT"}
+		if !reflect.DeepEqual(tdb.queries, want) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		attempts := 0
+		err := InTx(ctx, db, noWait, func(tx *Tx) error {
+			attempts++
+			if attempts < 3 {
+				return &testPgError{"40001"}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+		want := []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK", "BEGIN", "COMMIT"}
+		if !reflect.DeepEqual(tdb.queries, want) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		db, _ := openTestDB(t, nil)
+
+		attempts := 0
+		opts := *noWait
+		opts.MaxAttempts = 2
+		err := InTx(ctx, db, &opts, func(tx *Tx) error {
+			attempts++
+			return &testPgError{"40P01"}
+		})
+		if !IsRetryable(err) || attempts != 2 {
+			t.Errorf("invalid error: %v after %d attempts", err, attempts)
+		}
+	})
+
+	t.Run("no retry", func(t *testing.T) {
+		db, _ := openTestDB(t, nil)
+
+		attempts := 0
+		err := InTx(ctx, db, noWait, func(tx *Tx) error {
+			attempts++
+			return &testPgError{"23505"}
+		})
+		if err == nil || attempts != 1 {
+			t.Errorf("invalid error: %v after %d attempts", err, attempts)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		func() {
+			defer func() {
+				if p := recover(); p != "boom" {
+					t.Errorf("invalid panic: %v", p)
+				}
+			}()
+			InTx(ctx, db, nil, func(tx *Tx) error {
+				panic("boom")
+			})
+		}()
+		if !reflect.DeepEqual(tdb.queries, []string{"BEGIN", "ROLLBACK"}) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("savepoint", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+
+		err := InTx(ctx, db, nil, func(tx *Tx) error {
+			if err := InTx(ctx, tx, nil, func(tx *Tx) error {
+				_, err := Delete("sessions").Where("user_id", 7).Exec(ctx, tx)
+				return err
+			}); err != nil {
+				return err
+			}
+
+			err := InTx(ctx, tx, nil, func(tx *Tx) error {
+				return InTx(ctx, tx, nil, func(tx *Tx) error {
+					return errors.New("failed")
+				})
+			})
+			if err == nil {
+				t.Error("invalid error: nil")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("invalid error: %v", err)
+		}
+
+		want := []string{
+			"BEGIN",
+			"SAVEPOINT sqls_1", "DELETE FROM sessions WHERE user_id=$1", "RELEASE SAVEPOINT sqls_1",
+			"SAVEPOINT sqls_1", "SAVEPOINT sqls_2", "ROLLBACK TO SAVEPOINT sqls_2", "ROLLBACK TO SAVEPOINT sqls_1",
+			"COMMIT",
+		}
+		if !reflect.DeepEqual(tdb.queries, want) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("nested deadlock", func(t *testing.T) {
+		db, tdb := openTestDB(t, nil)
+		tdb.errs = map[string]error{"ROLLBACK TO SAVEPOINT sqls_1": errors.New("current transaction is aborted")}
+
+		attempts := 0
+		err := InTx(ctx, db, noWait, func(tx *Tx) error {
+			attempts++
+			return InTx(ctx, tx, nil, func(tx *Tx) error {
+				if attempts < 2 {
+					return &testPgError{"40P01"}
+				}
+				return nil
+			})
+		})
+		if err != nil || attempts != 2 {
+			t.Errorf("invalid error: %v after %d attempts", err, attempts)
+		}
+		want := []string{
+			"BEGIN", "SAVEPOINT sqls_1", "ROLLBACK TO SAVEPOINT sqls_1", "ROLLBACK",
+			"BEGIN", "SAVEPOINT sqls_1", "RELEASE SAVEPOINT sqls_1", "COMMIT",
+		}
+		if !reflect.DeepEqual(tdb.queries, want) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("sql server savepoint", func(t *testing.T) {
+		SetDialect(SQLServer)
+		defer SetDialect(PostgreSQL)
+		db, tdb := openTestDB(t, nil)
+
+		tx, _ := db.Begin()
+		InTx(ctx, tx, nil, func(tx *Tx) error { return nil })
+		InTx(ctx, tx, nil, func(tx *Tx) error { return errors.New("failed") })
+		tx.Commit()
+
+		want := []string{"BEGIN", "SAVE TRANSACTION sqls_1", "SAVE TRANSACTION sqls_1", "ROLLBACK TRANSACTION sqls_1", "COMMIT"}
+		if !reflect.DeepEqual(tdb.queries, want) {
+			t.Errorf("invalid queries: '%v'", tdb.queries)
+		}
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		db, _ := openTestDB(t, nil)
+
+		err := InTx(ctx, NewStmtCache(db, 1), nil, func(tx *Tx) error { return nil })
+		if !errors.Is(err, ErrNotSupported) {
+			t.Errorf("invalid error: %v", err)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	var tests = []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 5 * time.Millisecond, 10 * time.Millisecond},
+		{3, 20 * time.Millisecond, 40 * time.Millisecond},
+		{20, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			if d := backoff(tt.attempt); d < tt.min || d > tt.max {
+				t.Errorf("attempt %d: invalid backoff %v", tt.attempt, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package sqls
+
+import (
+	"fmt"
+	"slices"
+)
+
+// UpdateManyStmt represents an SQL UPDATE statement that sets different values for many rows.
+type UpdateManyStmt struct {
+	table   string
+	key     string
+	columns []string
+	types   []string
+	args    []any
+	count   int
+	widths  rowWidths
+}
+
+// UpdateMany creates a new UPDATE statement for multiple rows.
+func UpdateMany(table string) *UpdateManyStmt {
+	return &UpdateManyStmt{
+		table: table,
+	}
+}
+
+// Key specifies the column used to match the rows to be updated.
+func (s *UpdateManyStmt) Key(column string) *UpdateManyStmt {
+	s.key = column
+	return s
+}
+
+// Columns specifies the columns to be updated in the UPDATE statement.
+func (s *UpdateManyStmt) Columns(columns ...string) *UpdateManyStmt {
+	s.columns = columns
+	return s
+}
+
+// Types specifies the SQL types of the key and the columns. PostgreSQL requires
+// them, since the values of the VALUES list are text otherwise and cannot be
+// compared to the key or assigned to other columns. Other dialects ignore them.
+func (s *UpdateManyStmt) Types(types ...string) *UpdateManyStmt {
+	s.types = types
+	return s
+}
+
+// Values adds a row identified by its key value to the UPDATE statement.
+func (s *UpdateManyStmt) Values(key any, values ...any) *UpdateManyStmt {
+	s.args = append(s.args, key)
+	s.args = append(s.args, values...)
+	s.count++
+	s.widths.add(s.count, len(values))
+	return s
+}
+
+// Clear resets the rows to be updated in the UPDATE statement.
+func (s *UpdateManyStmt) Clear() *UpdateManyStmt {
+	s.args = []any{}
+	s.count = 0
+	s.widths = rowWidths{}
+	return s
+}
+
+// Clone returns a deep copy of the UPDATE statement.
+func (s *UpdateManyStmt) Clone() *UpdateManyStmt {
+	c := *s
+	c.columns = slices.Clone(s.columns)
+	c.types = slices.Clone(s.types)
+	c.args = slices.Clone(s.args)
+	return &c
+}
+
+// Chunks splits the UPDATE statement into statements that stay within the
+// parameter limit of the current dialect, and within 1000 rows on SQL Server.
+// An invalid statement is not split.
+func (s *UpdateManyStmt) Chunks() []*UpdateManyStmt {
+	d := curDialect.Load()
+	length := len(s.columns) + 1
+	size := d.chunkSize(length)
+	if s.count <= size || s.validate(d) != nil {
+		return []*UpdateManyStmt{s}
+	}
+
+	chunks := make([]*UpdateManyStmt, 0, (s.count+size-1)/size)
+	for i := 0; i < s.count; i += size {
+		count := min(size, s.count-i)
+		chunk := *s
+		chunk.args = s.args[i*length : (i+count)*length : (i+count)*length]
+		chunk.count = count
+		chunks = append(chunks, &chunk)
+	}
+	return chunks
+}
+
+// ToSql generates the SQL and returns the parameters.
+func (s *UpdateManyStmt) ToSql() (string, []any) {
+	return render(curDialect.Load(), s.appendSql, s.args)
+}
+
+// AppendSql appends the SQL to buf and returns the extended buffer and the parameters.
+func (s *UpdateManyStmt) AppendSql(buf []byte) ([]byte, []any) {
+	d := curDialect.Load()
+	return d.bindOrder(s.appendSql(buf, d), len(buf), s.args)
+}
+
+func (s *UpdateManyStmt) appendSql(b []byte, d *Dialect) []byte {
+	alias := tableAlias(s.table)
+
+	switch d.kind {
+	case kindPostgreSQL:
+		// UPDATE t SET c=v.c FROM (VALUES ...) AS v(id,c) WHERE t.id=v.id
+		b = append(b, "UPDATE "...)
+		b = append(b, s.table...)
+		b = s.appendAssignments(b, "")
+		b = append(b, " FROM ("...)
+		b = s.appendValues(b, d, true)
+		b = s.appendAlias(b)
+		b = append(b, " WHERE "...)
+		return s.appendKeyMatch(b, alias)
+	case kindSQLServer:
+		// UPDATE t SET c=v.c FROM t JOIN (VALUES ...) AS v(id,c) ON t.id=v.id
+		b = append(b, "UPDATE "...)
+		b = append(b, alias...)
+		b = s.appendAssignments(b, "")
+		b = append(b, " FROM "...)
+		b = append(b, s.table...)
+		b = append(b, " JOIN ("...)
+		b = s.appendValues(b, d, false)
+		b = s.appendAlias(b)
+		b = append(b, " ON "...)
+		return s.appendKeyMatch(b, alias)
+	case kindMySQL:
+		// UPDATE t JOIN (SELECT ... UNION ALL SELECT ...) AS v ON t.id=v.id SET t.c=v.c
+		b = append(b, "UPDATE "...)
+		b = append(b, s.table...)
+		b = append(b, " JOIN ("...)
+		b = s.appendUnion(b, d)
+		b = append(b, ") AS v ON "...)
+		b = s.appendKeyMatch(b, alias)
+		return s.appendAssignments(b, alias+".")
+	default:
+		// UPDATE t SET c=CASE id WHEN ... THEN ... END WHERE id IN (...)
+		return s.appendCase(b, d)
+	}
+}
+
+// Build generates the SQL and returns the parameters.
+// It returns a *BuildError if the statement is invalid, or on PostgreSQL if it
+// does not have one type for the key and each column.
+func (s *UpdateManyStmt) Build() (string, []any, error) {
+	d := curDialect.Load()
+	if err := s.validate(d); err != nil {
+		return "", nil, err
+	}
+
+	query, args := render(d, s.appendSql, s.args)
+	return query, args, nil
+}
+
+func (s *UpdateManyStmt) validate(d *Dialect) error {
+	if s.table == "" {
+		return &BuildError{Statement: "UPDATE", Err: ErrNoTable}
+	}
+	if s.key == "" {
+		return &BuildError{Statement: "UPDATE", Err: ErrNoKey}
+	}
+	if s.columns == nil {
+		return &BuildError{Statement: "UPDATE", Err: ErrNoColumns}
+	}
+	if s.count == 0 {
+		return &BuildError{Statement: "UPDATE", Err: ErrNoRows}
+	}
+	if err := s.widths.check(len(s.columns)); err != nil {
+		return err.in("UPDATE")
+	}
+	if d.kind == kindPostgreSQL && len(s.types) != len(s.columns)+1 {
+		return &BuildError{Statement: "UPDATE", Err: ErrNoTypes,
+			Detail: fmt.Sprintf("%d types for the key and %d columns", len(s.types), len(s.columns))}
+	}
+	return nil
+}
+
+// appendAssignments appends the SET clause assigning the columns from the values table v.
+func (s *UpdateManyStmt) appendAssignments(b []byte, prefix string) []byte {
+	b = append(b, " SET "...)
+	for i, c := range s.columns {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, prefix...)
+		b = append(b, c...)
+		b = append(b, "=v."...)
+		b = append(b, c...)
+	}
+	return b
+}
+
+// appendAlias appends the alias and column names of the values table v.
+func (s *UpdateManyStmt) appendAlias(b []byte) []byte {
+	b = append(b, ") AS v("...)
+	b = append(b, s.key...)
+	b = append(b, ',')
+	b = appendList(b, s.columns, ",")
+	return append(b, ')')
+}
+
+func (s *UpdateManyStmt) appendKeyMatch(b []byte, alias string) []byte {
+	b = append(b, alias...)
+	b = append(b, '.')
+	b = append(b, s.key...)
+	b = append(b, "=v."...)
+	return append(b, s.key...)
+}
+
+func (s *UpdateManyStmt) appendValues(b []byte, d *Dialect, cast bool) []byte {
+	length := len(s.columns) + 1
+
+	b = append(b, "VALUES "...)
+	for i := 1; i <= s.count*length; i += length {
+		if i > 1 {
+			b = append(b, ',')
+		}
+		b = append(b, '(')
+		if i == 1 && cast && s.types != nil {
+			// PostgreSQL takes the column types of the VALUES list from the first row
+			for j := 0; j < length; j++ {
+				if j > 0 {
+					b = append(b, ',')
+				}
+				b = d.appendParams(b, i+j, 1)
+				if j < len(s.types) {
+					b = append(b, "::"...)
+					b = append(b, s.types[j]...)
+				}
+			}
+		} else {
+			b = d.appendParams(b, i, length)
+		}
+		b = append(b, ')')
+	}
+	return b
+}
+
+func (s *UpdateManyStmt) appendUnion(b []byte, d *Dialect) []byte {
+	length := len(s.columns) + 1
+
+	for i := 1; i <= s.count*length; i += length {
+		if i > 1 {
+			b = append(b, " UNION ALL "...)
+		}
+		b = append(b, "SELECT "...)
+		if i > 1 {
+			b = d.appendParams(b, i, length)
+			continue
+		}
+		// the first row names the columns
+		b = d.appendParams(b, 1, 1)
+		b = append(b, " AS "...)
+		b = append(b, s.key...)
+		for j, c := range s.columns {
+			b = append(b, ',')
+			b = d.appendParams(b, j+2, 1)
+			b = append(b, " AS "...)
+			b = append(b, c...)
+		}
+	}
+	return b
+}
+
+func (s *UpdateManyStmt) appendCase(b []byte, d *Dialect) []byte {
+	length := len(s.columns) + 1
+	end := s.count * length
+
+	b = append(b, "UPDATE "...)
+	b = append(b, s.table...)
+	b = append(b, " SET "...)
+	for j, c := range s.columns {
+		if j > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, c...)
+		b = append(b, "=CASE "...)
+		b = append(b, s.key...)
+		for i := 1; i <= end; i += length {
+			b = append(b, " WHEN "...)
+			b = d.appendParams(b, i, 1)
+			b = append(b, " THEN "...)
+			b = d.appendParams(b, i+j+1, 1)
+		}
+		b = append(b, " END"...)
+	}
+
+	b = append(b, " WHERE "...)
+	b = append(b, s.key...)
+	b = append(b, " IN ("...)
+	for i := 1; i <= end; i += length {
+		if i > 1 {
+			b = append(b, ',')
+		}
+		b = d.appendParams(b, i, 1)
+	}
+	return append(b, ')')
+}
@@ -0,0 +1,96 @@
+package sqls
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateMany(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		want    string
+		args    []any
+	}{
+		{"postgres", PostgreSQL, "UPDATE users SET name=v.name,age=v.age FROM (VALUES ($1::int,$2::text,$3::int),($4,$5,$6)) AS v(id,name,age) WHERE users.id=v.id", []any{1, "John", 30, 2, "Jane", 25}},
+		{"sql server", SQLServer, "UPDATE users SET name=v.name,age=v.age FROM users JOIN (VALUES (@1,@2,@3),(@4,@5,@6)) AS v(id,name,age) ON users.id=v.id", []any{1, "John", 30, 2, "Jane", 25}},
+		{"mysql", MySQL, "UPDATE users JOIN (SELECT ? AS id,? AS name,? AS age UNION ALL SELECT ?,?,?) AS v ON users.id=v.id SET users.name=v.name,users.age=v.age", []any{1, "John", 30, 2, "Jane", 25}},
+		// SQLite numbers the placeholders in the order they first appear
+		{"sqlite", SQLite, "UPDATE users SET name=CASE id WHEN @1 THEN @2 WHEN @3 THEN @4 END,age=CASE id WHEN @1 THEN @5 WHEN @3 THEN @6 END WHERE id IN (@1,@3)", []any{1, "John", 2, "Jane", 30, 25}},
+		{"default", DefaultDialect, "UPDATE users SET name=CASE id WHEN @1 THEN @2 WHEN @3 THEN @4 END,age=CASE id WHEN @1 THEN @5 WHEN @3 THEN @6 END WHERE id IN (@1,@3)", []any{1, "John", 2, "Jane", 30, 25}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args := UpdateMany("users").
+				Key("id").
+				Columns("name", "age").
+				Types("int", "text", "int").
+				Values(1, "John", 30).
+				Values(2, "Jane", 25).
+				ToSql()
+
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("invalid args: '%v'", args)
+			}
+		})
+	}
+
+	t.Run("Update many chunks", func(t *testing.T) {
+		SetDialect(SQLite)
+
+		s := UpdateMany("users").Key("id").Columns("name", "age")
+		for i := 0; i < 700; i++ {
+			s.Values(i, "name", i)
+		}
+
+		chunks := s.Chunks()
+		if len(chunks) != 3 {
+			t.Fatalf("invalid chunks: %d", len(chunks))
+		}
+		if chunks[0].count != 333 || chunks[1].count != 333 || chunks[2].count != 34 {
+			t.Errorf("invalid chunk sizes: %d, %d, %d", chunks[0].count, chunks[1].count, chunks[2].count)
+		}
+
+		_, args := chunks[2].ToSql()
+		if len(args) != 34*3 || args[0] != 666 {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+}
+
+func TestUpdateManyBuild(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	if _, _, err := UpdateMany("users").Columns("name").Values(1, "John").Build(); !errors.Is(err, ErrNoKey) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, _, err := UpdateMany("users").Key("id").Columns("name").Values(1).Build(); !errors.Is(err, ErrValueCount) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, _, err := UpdateMany("users").Key("id").Columns("name").Types("int", "text").Values(1, "John").Build(); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, _, err := UpdateMany("users").Key("id").Values(1, "John").Columns("name").Types("int", "text").Build(); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	// PostgreSQL cannot compare or assign the untyped values
+	if _, _, err := UpdateMany("users").Key("id").Columns("name", "age").Values(1, "John", 30).Build(); !errors.Is(err, ErrNoTypes) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, _, err := UpdateMany("users").Key("id").Columns("name", "age").Types("int", "text").Values(1, "John", 30).Build(); !errors.Is(err, ErrNoTypes) {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	SetDialect(MySQL)
+	if _, _, err := UpdateMany("users").Key("id").Columns("name").Values(1, "John").Build(); err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+}
@@ -1,14 +1,20 @@
 package sqls
 
 import (
-	"strings"
+	"slices"
 )
 
 // UpdateStmt represents an SQL UPDATE statement.
 type UpdateStmt struct {
 	table   string
 	columns []string
+	from    []string
+	joins   []join
 	whereClause
+	orderBy []string
+	limit   int
+	key     string
+	allRows bool
 }
 
 // Update creates a new UPDATE statement.
@@ -21,7 +27,7 @@ func Update(table string) *UpdateStmt {
 // Set adds a column and its corresponding value to the UPDATE statement.
 func (s *UpdateStmt) Set(column string, value any) *UpdateStmt {
 	s.args = append(s.args, value)
-	p := params(len(s.args), 1)
+	p := s.d().params(len(s.args), 1)
 	s.columns = append(s.columns, column+"="+p)
 	return s
 }
@@ -30,7 +36,7 @@ func (s *UpdateStmt) Set(column string, value any) *UpdateStmt {
 func (s *UpdateStmt) SetValues(values []KeyVal) *UpdateStmt {
 	for _, kv := range values {
 		s.args = append(s.args, kv.val)
-		p := params(len(s.args), 1)
+		p := s.d().params(len(s.args), 1)
 		s.columns = append(s.columns, kv.key+"="+p)
 	}
 	return s
@@ -42,6 +48,12 @@ func (s *UpdateStmt) Where(column string, value any) *UpdateStmt {
 	return s
 }
 
+// WhereNotEquals adds a WHERE column<>value clause to the UPDATE statement.
+func (s *UpdateStmt) WhereNotEquals(column string, value any) *UpdateStmt {
+	s.whereNotEquals(column, value)
+	return s
+}
+
 // WhereNull adds a WHERE clause checking for NULL to the UPDATE statement.
 func (s *UpdateStmt) WhereNull(column string) *UpdateStmt {
 	s.whereNull(column)
@@ -54,7 +66,9 @@ func (s *UpdateStmt) WhereNotNull(column string) *UpdateStmt {
 	return s
 }
 
-// WhereExp adds a WHERE clause with a custom expression to the UPDATE statement.
+// WhereExp adds a WHERE column operator value clause to the UPDATE statement.
+// The operator must be one of the Op constants, see ParseOp. LIKE operators get
+// the ESCAPE clause of WhereLike.
 func (s *UpdateStmt) WhereExp(column string, eq string, value any) *UpdateStmt {
 	s.whereExp(column, eq, value)
 	return s
@@ -66,19 +80,256 @@ func (s *UpdateStmt) WhereIn(column string, in []any) *UpdateStmt {
 	return s
 }
 
+// WhereNotIn adds a WHERE column NOT IN (values) clause to the UPDATE statement.
+// An empty list adds no clause, so it does not scope the statement.
+func (s *UpdateStmt) WhereNotIn(column string, values []any) *UpdateStmt {
+	s.whereNotIn(column, values)
+	return s
+}
+
+// WhereAny adds a WHERE column=ANY(values) clause to the UPDATE statement. The slice
+// is bound as a single array argument on PostgreSQL and expanded into a WHERE
+// column IN (values) clause on other dialects.
+func (s *UpdateStmt) WhereAny(column string, values any) *UpdateStmt {
+	s.whereAny(column, values)
+	return s
+}
+
+// WhereBetween adds a WHERE column BETWEEN from AND to clause to the UPDATE statement.
+func (s *UpdateStmt) WhereBetween(column string, from any, to any) *UpdateStmt {
+	s.whereBetween(column, ` BETWEEN `, from, to)
+	return s
+}
+
+// WhereNotBetween adds a WHERE column NOT BETWEEN from AND to clause to the UPDATE statement.
+func (s *UpdateStmt) WhereNotBetween(column string, from any, to any) *UpdateStmt {
+	s.whereBetween(column, ` NOT BETWEEN `, from, to)
+	return s
+}
+
+// WhereLike adds a WHERE column LIKE value clause to the UPDATE statement.
+// The % and _ wildcards in value are escaped so it matches literally, use
+// WhereLikePattern for a pattern.
+func (s *UpdateStmt) WhereLike(column string, value string) *UpdateStmt {
+	s.whereLike(column, ` LIKE `, EscapeLike(value))
+	return s
+}
+
+// WhereNotLike adds a WHERE column NOT LIKE value clause to the UPDATE statement.
+// The value is escaped, see WhereLike.
+func (s *UpdateStmt) WhereNotLike(column string, value string) *UpdateStmt {
+	s.whereLike(column, ` NOT LIKE `, EscapeLike(value))
+	return s
+}
+
+// WhereILike adds a case-insensitive WHERE column ILIKE value clause to the UPDATE
+// statement. Dialects without ILIKE compare LOWER(column) and LOWER(value).
+// The value is escaped, see WhereLike.
+func (s *UpdateStmt) WhereILike(column string, value string) *UpdateStmt {
+	s.whereILike(column, ILike, EscapeLike(value))
+	return s
+}
+
+// WhereLikePattern adds a WHERE column LIKE pattern clause to the UPDATE statement.
+// The pattern is used as is, so % and _ in it are wildcards: build it with
+// Contains, HasPrefix, HasSuffix or EscapeLike to match user input literally.
+func (s *UpdateStmt) WhereLikePattern(column string, pattern string) *UpdateStmt {
+	s.whereLike(column, ` LIKE `, pattern)
+	return s
+}
+
+// WhereNotLikePattern adds a WHERE column NOT LIKE pattern clause to the UPDATE
+// statement. The pattern is used as is, see WhereLikePattern.
+func (s *UpdateStmt) WhereNotLikePattern(column string, pattern string) *UpdateStmt {
+	s.whereLike(column, ` NOT LIKE `, pattern)
+	return s
+}
+
+// WhereILikePattern adds a case-insensitive WHERE column ILIKE pattern clause to
+// the UPDATE statement. The pattern is used as is, see WhereLikePattern.
+func (s *UpdateStmt) WhereILikePattern(column string, pattern string) *UpdateStmt {
+	s.whereILike(column, ILike, pattern)
+	return s
+}
+
+// WhereRow adds a WHERE (columns) operator (values) row value comparison to the UPDATE
+// statement, such as (created_at,id)>($1,$2). The operator must be =, <>, <, <=, > or >=.
+func (s *UpdateStmt) WhereRow(columns []string, ex string, values []any) *UpdateStmt {
+	s.whereRow(columns, ex, values)
+	return s
+}
+
 // WhereRaw adds a raw WHERE clause to the UPDATE statement.
 func (s *UpdateStmt) WhereRaw(raw string) *UpdateStmt {
 	s.whereRaw(raw)
 	return s
 }
 
+// From adds tables to the FROM clause of the UPDATE statement.
+func (s *UpdateStmt) From(tables ...string) *UpdateStmt {
+	s.from = append(s.from, tables...)
+	return s
+}
+
+// Join adds a JOIN table ON on1=on2 clause to the UPDATE statement.
+func (s *UpdateStmt) Join(table string, on1 string, on2 string) *UpdateStmt {
+	s.joins = append(s.joins, join{table: table, on: []string{on1 + "=" + on2}})
+	return s
+}
+
+// On adds a column=value condition to the last JOIN clause of the UPDATE statement.
+func (s *UpdateStmt) On(column string, value any) *UpdateStmt {
+	s.joinOn(s.joins, column, value)
+	return s
+}
+
+// OrderBy adds an ORDER BY clause to the UPDATE statement.
+func (s *UpdateStmt) OrderBy(columns ...string) *UpdateStmt {
+	s.orderBy = columns
+	return s
+}
+
+// Limit adds a LIMIT clause to the UPDATE statement.
+func (s *UpdateStmt) Limit(limit int) *UpdateStmt {
+	s.limit = limit
+	return s
+}
+
+// Key sets the key column of the table. PostgreSQL has no ORDER BY and LIMIT in
+// UPDATE, so they are emulated by matching the key to a subquery:
+// WHERE key IN (SELECT key FROM table WHERE ... ORDER BY ... LIMIT n).
+func (s *UpdateStmt) Key(column string) *UpdateStmt {
+	s.key = column
+	return s
+}
+
+// AllRows allows the UPDATE statement to be built without a WHERE clause.
+func (s *UpdateStmt) AllRows() *UpdateStmt {
+	s.allRows = true
+	return s
+}
+
+// Clone returns a deep copy of the UPDATE statement, so a base statement can be
+// extended without affecting it or other statements cloned from it.
+func (s *UpdateStmt) Clone() *UpdateStmt {
+	c := *s
+	c.columns = slices.Clone(s.columns)
+	c.from = slices.Clone(s.from)
+	c.joins = cloneJoins(s.joins)
+	c.whereClause = s.whereClause.clone()
+	c.orderBy = slices.Clone(s.orderBy)
+	return &c
+}
+
 // ToSql generates the SQL query string and the corresponding arguments for the UPDATE statement.
+// It does not validate the statement nor apply the unscoped policy, use Build or Exec for that.
 func (s *UpdateStmt) ToSql() (string, []any) {
-	query := "UPDATE " + s.table + " SET " + strings.Join(s.columns, ",")
+	return render(s.renderDialect(), s.appendSql, s.args)
+}
 
-	if s.where != nil {
-		query += " WHERE " + strings.Join(s.where, " AND ")
+// AppendSql appends the SQL query of the UPDATE statement to buf and returns the
+// extended buffer and the corresponding arguments.
+func (s *UpdateStmt) AppendSql(buf []byte) ([]byte, []any) {
+	d := s.renderDialect()
+	return d.bindOrder(s.appendSql(buf, d), len(buf), s.args)
+}
+
+// Build generates the SQL query string and the corresponding arguments for the UPDATE statement.
+// It returns a *BuildError if the statement is invalid or cannot be rendered for the
+// current dialect, or an error wrapping ErrUnscoped if it has no WHERE clause and is
+// not allowed by the unscoped policy.
+func (s *UpdateStmt) Build() (string, []any, error) {
+	if s.table == "" {
+		return "", nil, &BuildError{Statement: "UPDATE", Err: ErrNoTable}
+	}
+	if s.columns == nil {
+		return "", nil, &BuildError{Statement: "UPDATE", Err: ErrNoColumns}
 	}
+	if err := s.check("UPDATE"); err != nil {
+		return "", nil, err
+	}
+	if s.limit < 0 {
+		return "", nil, &BuildError{Statement: "UPDATE", Err: ErrNegativeLimit}
+	}
+	if err := s.checkScope(s.table, s.allRows); err != nil {
+		return "", nil, err
+	}
+	d := s.renderDialect()
+	if err := s.checkOrderLimit(d); err != nil {
+		return "", nil, err
+	}
+
+	query, args := render(d, s.appendSql, s.args)
+	return query, args, nil
+}
+
+// checkOrderLimit returns a *BuildError if the ORDER BY and LIMIT of the
+// statement cannot be rendered for the dialect.
+func (s *UpdateStmt) checkOrderLimit(d *Dialect) error {
+	if s.orderBy == nil && s.limit <= 0 {
+		return nil
+	}
+	return checkOrderLimit(d, "UPDATE", s.key, s.from != nil || s.joins != nil)
+}
+
+func (s *UpdateStmt) appendSql(b []byte, d *Dialect) []byte {
+	if s.orderBy == nil && s.limit <= 0 {
+		return s.appendJoinSql(b, d)
+	}
+
+	if d.kind == kindPostgreSQL && s.key != "" && s.from == nil && s.joins == nil {
+		// UPDATE t SET ... WHERE id IN (SELECT id FROM t WHERE ... ORDER BY ... LIMIT n)
+		b = append(b, "UPDATE "...)
+		b = append(b, s.table...)
+		b = s.appendSet(b)
+		b = append(b, " WHERE "...)
+		b = append(b, s.key...)
+		b = append(b, " IN (SELECT "...)
+		b = append(b, s.key...)
+		b = append(b, " FROM "...)
+		b = append(b, s.table...)
+		b = appendWhere(b, s.where)
+		b = appendOrderLimit(b, s.orderBy, s.limit)
+		return append(b, ')')
+	}
+	// rendered natively, Build reports the dialects that do not support it
+	b = s.appendJoinSql(b, d)
+	return appendOrderLimit(b, s.orderBy, s.limit)
+}
+
+func (s *UpdateStmt) appendSet(b []byte) []byte {
+	b = append(b, " SET "...)
+	return appendList(b, s.columns, ",")
+}
+
+func (s *UpdateStmt) appendJoinSql(b []byte, d *Dialect) []byte {
+	b = append(b, "UPDATE "...)
 
-	return query, s.args
+	if s.from == nil && s.joins == nil {
+		b = append(b, s.table...)
+		b = s.appendSet(b)
+		return appendWhere(b, s.where)
+	}
+
+	switch d.kind {
+	case kindMySQL:
+		// UPDATE t JOIN x ON ... SET ... WHERE ...
+		b = appendTables(b, s.table, s.from, s.joins)
+		b = s.appendSet(b)
+		return appendWhere(b, s.where)
+	case kindSQLServer:
+		// UPDATE t SET ... FROM t JOIN x ON ... WHERE ...
+		b = append(b, tableAlias(s.table)...)
+		b = s.appendSet(b)
+		b = append(b, " FROM "...)
+		b = appendTables(b, s.table, s.from, s.joins)
+		return appendWhere(b, s.where)
+	default:
+		// UPDATE t SET ... FROM x WHERE ...
+		b = append(b, s.table...)
+		b = s.appendSet(b)
+		b = append(b, " FROM "...)
+		b, conds := appendFrom(b, s.from, s.joins)
+		return appendWhere(b, conds, s.where)
+	}
 }
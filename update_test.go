@@ -1,6 +1,7 @@
 package sqls
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -59,3 +60,184 @@ func TestUpdate(t *testing.T) {
 	})
 
 }
+
+func TestUpdateJoin(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		want    string
+		args    []any
+	}{
+		{"postgres", PostgreSQL, "UPDATE orders o SET status=$1 FROM customers c WHERE o.customer_id=c.id AND c.region=$2 AND c.active=$3",
+			[]any{"shipped", "EU", true}},
+		{"sqlite", SQLite, "UPDATE orders o SET status=@1 FROM customers c WHERE o.customer_id=c.id AND c.region=@2 AND c.active=@3",
+			[]any{"shipped", "EU", true}},
+		// ? placeholders are bound in the order they appear
+		{"mysql", MySQL, "UPDATE orders o JOIN customers c ON o.customer_id=c.id AND c.region=? SET status=? WHERE c.active=?",
+			[]any{"EU", "shipped", true}},
+		{"sql server", SQLServer, "UPDATE o SET status=@1 FROM orders o JOIN customers c ON o.customer_id=c.id AND c.region=@2 WHERE c.active=@3",
+			[]any{"shipped", "EU", true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args := Update("orders o").
+				Set("status", "shipped").
+				Join("customers c", "o.customer_id", "c.id").
+				On("c.region", "EU").
+				Where("c.active", true).
+				ToSql()
+
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("invalid args: '%v'", args)
+			}
+		})
+	}
+
+	t.Run("Update from", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args := Update("orders").
+			Set("discount", 10).
+			From("customers").
+			Join("tiers", "customers.tier_id", "tiers.id").
+			WhereRaw("orders.customer_id=customers.id").
+			Where("tiers.name", "gold").
+			ToSql()
+
+		if sql != "UPDATE orders SET discount=$1 FROM customers,tiers WHERE customers.tier_id=tiers.id AND orders.customer_id=customers.id AND tiers.name=$2" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{10, "gold"}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+
+	t.Run("Update two joins", func(t *testing.T) {
+		SetDialect(PostgreSQL)
+
+		sql, args := Update("orders o").
+			Set("status", "held").
+			Join("customers c", "o.customer_id", "c.id").
+			Join("items i", "i.order_id", "o.id").
+			On("i.sku", "X1").
+			Where("c.region", "EU").
+			ToSql()
+
+		if sql != "UPDATE orders o SET status=$1 FROM customers c,items i WHERE o.customer_id=c.id AND i.order_id=o.id AND i.sku=$2 AND c.region=$3" {
+			t.Errorf("invalid sql: '%s'", sql)
+		}
+		if !reflect.DeepEqual(args, []any{"held", "X1", "EU"}) {
+			t.Errorf("invalid args: '%v'", args)
+		}
+	})
+}
+
+func TestUpdateLimit(t *testing.T) {
+	var tests = []struct {
+		name    string
+		dialect Dialect
+		key     string
+		want    string
+		err     error
+	}{
+		{"mysql", MySQL, "", "UPDATE jobs SET state=? WHERE state=? ORDER BY id LIMIT 10", nil},
+		{"postgres", PostgreSQL, "id", "UPDATE jobs SET state=$1 WHERE id IN (SELECT id FROM jobs WHERE state=$2 ORDER BY id LIMIT 10)", nil},
+		{"postgres without key", PostgreSQL, "", "", ErrNoKey},
+		{"default", DefaultDialect, "id", "", ErrNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDialect(tt.dialect)
+
+			sql, args, err := Update("jobs").
+				Set("state", "running").
+				Where("state", "queued").
+				OrderBy("id").
+				Limit(10).
+				Key(tt.key).
+				Build()
+
+			if sql != tt.want {
+				t.Errorf("invalid sql: '%s'", sql)
+			}
+			if tt.err == nil && !reflect.DeepEqual(args, []any{"running", "queued"}) {
+				t.Errorf("invalid args: '%v'", args)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("invalid error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateUnscoped(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	_, _, err := Update("users").Set("active", false).Build()
+	if !errors.Is(err, ErrUnscoped) {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	if _, _, err := Update("users").Set("active", false).WhereNotIn("id", nil).Build(); !errors.Is(err, ErrUnscoped) {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	SetDialect(MySQL)
+	if _, _, err := Update("users").Set("active", false).Limit(1 << 30).Build(); !errors.Is(err, ErrUnscoped) {
+		t.Errorf("invalid error: %v", err)
+	}
+	SetDialect(PostgreSQL)
+
+	sql, args, err := Update("users").Set("active", false).AllRows().Build()
+	if err != nil {
+		t.Errorf("invalid error: %v", err)
+	}
+	if sql != "UPDATE users SET active=$1" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{false}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+}
+
+func TestUpdateBuild(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	if _, _, err := Update("users").Where("id", 1).Build(); !errors.Is(err, ErrNoColumns) {
+		t.Errorf("invalid error: %v", err)
+	}
+	if _, _, err := Update("users").Set("active", true).Where("id", 1).Limit(-5).Build(); !errors.Is(err, ErrNegativeLimit) {
+		t.Errorf("invalid error: %v", err)
+	}
+}
+
+func TestUpdateClone(t *testing.T) {
+	SetDialect(PostgreSQL)
+
+	base := Update("orders o").Set("status", "shipped").Join("customers c", "o.customer_id", "c.id")
+	a := base.Clone().On("c.region", "EU").Where("o.id", 1)
+	b := base.Clone().Where("o.id", 2)
+
+	sql, args := a.ToSql()
+	if sql != "UPDATE orders o SET status=$1 FROM customers c WHERE o.customer_id=c.id AND c.region=$2 AND o.id=$3" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{"shipped", "EU", 1}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+
+	sql, args = b.ToSql()
+	if sql != "UPDATE orders o SET status=$1 FROM customers c WHERE o.customer_id=c.id AND o.id=$2" {
+		t.Errorf("invalid sql: '%s'", sql)
+	}
+	if !reflect.DeepEqual(args, []any{"shipped", 2}) {
+		t.Errorf("invalid args: '%v'", args)
+	}
+}